@@ -0,0 +1,221 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Handler identifies the behaviour of the handler registered for a route.
+// Each adapter translates it into the native handler type of its framework,
+// so the response is always written the way the framework recommends.
+type Handler uint8
+
+const (
+	// HandlerOK writes the plain text response "ok" and ignores the captured path variables
+	HandlerOK Handler = iota
+	// HandlerRouteInfo writes the route that handled the request and the captured path variables (see routeInfo)
+	HandlerRouteInfo
+	// HandlerParamRead reads every captured path variable and writes the values into the response (see joinParams),
+	// so the cost of materialising the path variables is measured also for the frameworks that extract them lazily
+	HandlerParamRead
+)
+
+// FrameworkAdapter hides the routing API of a web framework behind a common interface,
+// so the same route tables and benchmarks can be executed against every framework.
+//
+// An adapter is single use: the middlewares are added first, then the routes are registered and Build is called once.
+type FrameworkAdapter interface {
+	// Name returns the short name of the framework, used to label the sub-benchmarks
+	Name() string
+	// Version returns the module version of the framework compiled into the benchmark
+	Version() string
+	// TranslatePath converts a path declared with the {name} syntax into the framework path syntax
+	TranslatePath(path string) string
+	// Use adds a middleware to the chain executed for every request, the first added middleware being the outermost.
+	// It is called before the routes are registered.
+	Use(m Middleware)
+	// Register adds a route, whose path is translated by TranslatePath, to the framework router or returns an error if
+	// the framework rejects it
+	Register(route *Route, path string, handler Handler) error
+	// Build returns the http.Handler that serves all the registered routes
+	Build() http.Handler
+}
+
+var frameworks = map[string]func() FrameworkAdapter{}
+
+// registerFramework makes a framework available to the benchmarks.
+// It must be called from an init function of the adapter file.
+func registerFramework(name string, newAdapter func() FrameworkAdapter) {
+	if _, found := frameworks[name]; found {
+		panic(fmt.Sprintf("framework %s is already registered", name))
+	}
+	frameworks[name] = newAdapter
+}
+
+// frameworkNames returns the names of all registered frameworks in alphabetical order
+func frameworkNames() []string {
+	names := make([]string, 0, len(frameworks))
+	for name := range frameworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegistrationError reports a route that the framework rejected, usually because it conflicts with another route
+type RegistrationError struct {
+	Route *Route
+	Err   error
+}
+
+func (e *RegistrationError) Error() string {
+	return fmt.Sprintf("failed to register %s %s: %v", e.Route.Method, e.Route.Path, e.Err)
+}
+
+func (e *RegistrationError) Unwrap() error {
+	return e.Err
+}
+
+// newRouter creates a new adapter for the named framework, adds the middlewares, registers all the routes and builds
+// the router. The returned error is a *RegistrationError when a route path is invalid or when the framework rejects
+// a route.
+func newRouter(name string, routes []*Route, handler Handler, middlewares ...Middleware) (FrameworkAdapter, http.Handler, error) {
+	paths, err := translatePaths(routes, frameworks[name]().TranslatePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return registerRouter(name, routes, paths, handler, middlewares...)
+}
+
+// registerRouter is newRouter with the route paths already translated by translatePaths
+func registerRouter(name string, routes []*Route, paths []string, handler Handler, middlewares ...Middleware) (FrameworkAdapter, http.Handler, error) {
+	adapter := frameworks[name]()
+	for _, m := range middlewares {
+		adapter.Use(m)
+	}
+	for i, r := range routes {
+		if err := adapter.Register(r, paths[i], handler); err != nil {
+			return nil, nil, &RegistrationError{Route: r, Err: err}
+		}
+	}
+	return adapter, adapter.Build(), nil
+}
+
+// translatePaths parses the route paths (see parsePattern) and translates them into the framework syntax.
+// The returned error is a *RegistrationError when a path is invalid.
+func translatePaths(routes []*Route, translate func(path string) string) ([]string, error) {
+	paths := make([]string, len(routes))
+	for i, r := range routes {
+		if _, err := parsePattern(r.Path); err != nil {
+			return nil, &RegistrationError{Route: r, Err: err}
+		}
+		paths[i] = translate(r.Path)
+	}
+	return paths, nil
+}
+
+// recoverRegistration converts the panic raised by a framework that rejects a route into an error
+func recoverRegistration(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%v", r)
+	}
+}
+
+// routeInfo describes the route that handled a request together with the path variables captured by the framework.
+// It is the response body written by the handlers registered with HandlerRouteInfo. Like in joinParams, the leading
+// '/' of a catch-all value, which some frameworks keep, is removed.
+func routeInfo(route *Route, pathVar func(name string) string) string {
+	var sb strings.Builder
+	sb.WriteString(route.Method)
+	sb.WriteByte(' ')
+	sb.WriteString(route.Path)
+	for _, name := range route.Params() {
+		sb.WriteByte('\n')
+		sb.WriteString(name)
+		sb.WriteByte('=')
+		sb.WriteString(strings.TrimPrefix(pathVar(name), "/"))
+	}
+	return sb.String()
+}
+
+// joinParams reads the named path variables with the framework accessor and joins their values with '/'.
+// It is the response body written by the handlers registered with HandlerParamRead. The catch-all value is written
+// without the leading '/' that some frameworks keep, so the captured tail is identical for every framework.
+func joinParams(names []string, pathVar func(name string) string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return strings.TrimPrefix(pathVar(names[0]), "/")
+	}
+	var sb strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteByte('/')
+		}
+		sb.WriteString(strings.TrimPrefix(pathVar(name), "/"))
+	}
+	return sb.String()
+}
+
+// writePlainText writes a 200 plain text response for the frameworks that hand the http.ResponseWriter to the handler
+func writePlainText(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, body)
+}
+
+// renameCatchAll returns an accessor that reads the catch-all variable with the name used by the framework
+func renameCatchAll(route *Route, native string, pathVar func(name string) string) func(name string) string {
+	catchAll := route.CatchAll()
+	return func(name string) string {
+		if name == catchAll && catchAll != "" {
+			return pathVar(native)
+		}
+		return pathVar(name)
+	}
+}
+
+// pathTail returns the rest of the path after the given number of segments, without the leading '/'.
+// It extracts the catch-all value for the frameworks that match the tail without capturing it.
+func pathTail(path string, segments int) string {
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' {
+			if segments == 0 {
+				return path[i+1:]
+			}
+			segments--
+		}
+	}
+	return ""
+}
+
+var (
+	buildInfoOnce  sync.Once
+	moduleVersions map[string]string
+)
+
+// moduleVersion returns the version of the module compiled into the test binary
+func moduleVersion(modulePath string) string {
+	buildInfoOnce.Do(func() {
+		moduleVersions = make(map[string]string)
+		if bi, ok := debug.ReadBuildInfo(); ok {
+			for _, dep := range bi.Deps {
+				if dep.Replace != nil {
+					moduleVersions[dep.Path] = dep.Replace.Version
+				} else {
+					moduleVersions[dep.Path] = dep.Version
+				}
+			}
+		}
+	})
+	if v, found := moduleVersions[modulePath]; found {
+		return v
+	}
+	return "unknown"
+}
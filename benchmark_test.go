@@ -0,0 +1,327 @@
+package router
+
+import (
+	"flag"
+	"github.com/ixtendio/gofrebench/internal/hdr"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	recordLatency = flag.Bool("latency", true, "record the latency of every request and report its percentiles, the clock reads are included in ns/op")
+	parallelism   = flag.Int("parallelism", 0, "goroutines per GOMAXPROCS of the concurrent benchmarks, 0 means one per request of the scenario")
+)
+
+// scenario describes the requests fired against the routers built from a route set
+type scenario struct {
+	set     *RouteSet
+	handler Handler
+	// requests are served in random order by the concurrent benchmarks and in turn by the single-threaded ones
+	requests []*http.Request
+	// accept reports whether the response status code is the expected one
+	accept func(status int) bool
+	// feature, if not empty, names the optional framework feature exercised by the requests. A framework that doesn't
+	// answer every request as expected doesn't have the feature and is skipped instead of failed.
+	feature string
+	// allow requires the Allow header in the responses
+	allow bool
+	// middlewares are registered into the routers before the routes
+	middlewares []Middleware
+	// forSet builds the same scenario for another route set, the compatible variant of set
+	forSet func(set *RouteSet) *scenario
+}
+
+// routesScenario fires requests to the registered routes and expects them to be served by the route handlers
+func routesScenario(set *RouteSet, handler Handler, concurrent bool) *scenario {
+	s := &scenario{
+		set:     set,
+		handler: handler,
+		accept:  isStatusOK,
+	}
+	if concurrent {
+		s.requests = routeRequests(set, set.Routes)
+	} else {
+		s.requests = []*http.Request{httptest.NewRequest("GET", "https://www.domain.com"+set.HotPath, nil)}
+	}
+	s.forSet = func(set *RouteSet) *scenario {
+		return routesScenario(set, handler, concurrent)
+	}
+	return s
+}
+
+// pathScenario fires GET requests to a path of the route set and expects them to be served by the route handler
+func pathScenario(set *RouteSet, handler Handler, path string) *scenario {
+	return &scenario{
+		set:      set,
+		handler:  handler,
+		requests: []*http.Request{httptest.NewRequest("GET", "https://www.domain.com"+path, nil)},
+		accept:   isStatusOK,
+		forSet: func(set *RouteSet) *scenario {
+			return pathScenario(set, handler, path)
+		},
+	}
+}
+
+func isStatusOK(status int) bool {
+	return status == http.StatusOK
+}
+
+// newClientFactory verifies the framework conformance for the scenario route set, builds the router and probes the
+// scenario feature. The benchmark is skipped when the framework doesn't support the routes or the feature.
+func (s *scenario) newClientFactory(b *testing.B, framework string) func() client {
+	requireConformance(b, framework, s.set)
+	newClient, err := newClientFactory(framework, s.set.Routes, s.handler, s.middlewares...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if s.feature != "" {
+		for _, r := range s.requests {
+			// a new client for each request, the headers of a client are only reliable for its first response
+			c := newClient()
+			if status := c.do(r); !s.accept(status) {
+				b.Skipf("%s does not support %s: got %d for %s %s", framework, s.feature, status, r.Method, r.URL.Path)
+			}
+			if s.allow && c.header("Allow") == "" {
+				b.Skipf("%s does not support %s: no Allow header for %s %s", framework, s.feature, r.Method, r.URL.Path)
+			}
+		}
+	}
+	return newClient
+}
+
+// forFramework returns the scenario benchmarked for the framework, the scenario built for the compatible variant of the
+// route set when the framework cannot register the set
+func (s *scenario) forFramework(framework string) *scenario {
+	if set := frameworkRouteSet(framework, s.set); set != s.set {
+		return s.forSet(set)
+	}
+	return s
+}
+
+// runFrameworks runs one sub-benchmark per framework, in a child process unless the -inprocess flag is set.
+// The route set benchmarked for the framework and the framework version are printed before each sub-benchmark as
+// configuration lines, so the results parser attaches them to the result.
+func runFrameworks(b *testing.B, set *RouteSet, f func(b *testing.B, framework string)) {
+	benchmark := b.Name()
+	var routeSet string
+	for _, name := range benchmarkedFrameworks() {
+		if frameworkSet := frameworkRouteSet(name, set).Name; frameworkSet != routeSet {
+			routeSet = frameworkSet
+			printConfig("routeset", routeSet)
+		}
+		printConfig("framework-version", frameworkVersion(name))
+		b.Run(name, func(b *testing.B) {
+			if !*inProcess {
+				benchmarkIsolated(b, benchmark, name)
+				return
+			}
+			f(b, name)
+		})
+	}
+}
+
+func benchmarkScenario(b *testing.B, s *scenario) {
+	runFrameworks(b, s.set, func(b *testing.B, name string) {
+		s := s.forFramework(name)
+		c := s.newClientFactory(b, name)()
+		latency := &latencyRecorder{}
+		h := latency.histogram()
+		b.ResetTimer()
+		b.ReportAllocs()
+		last := time.Now()
+		for i, next := 0, 0; i < b.N; i++ {
+			r := s.requests[next]
+			if next++; next == len(s.requests) {
+				next = 0
+			}
+			if status := c.do(r); !s.accept(status) {
+				b.Fatalf("got %d for %s %s", status, r.Method, r.URL.Path)
+			}
+			if h != nil {
+				last = recordSince(h, last)
+			}
+		}
+		b.StopTimer()
+		latency.merge(h)
+		latency.report(b)
+	})
+}
+
+func benchmarkScenarioConcurrent(b *testing.B, s *scenario) {
+	seed := time.Now().UnixNano()
+	runFrameworks(b, s.set, func(b *testing.B, name string) {
+		s := s.forFramework(name)
+		requestsLen := len(s.requests)
+		newClient := s.newClientFactory(b, name)
+		latency := &latencyRecorder{}
+		p := requestsLen
+		if *parallelism > 0 {
+			p = *parallelism
+		}
+		b.SetParallelism(p)
+		// the state of the goroutines started by RunParallel is allocated before the timer is started
+		workers := make([]*worker, p*runtime.GOMAXPROCS(0))
+		for i := range workers {
+			workers[i] = &worker{
+				// every goroutine has its own source, the global one is guarded by a mutex that would serialize the goroutines
+				rnd:      rand.New(rand.NewSource(atomic.AddInt64(&seed, 1))),
+				c:        newClient(),
+				requests: cloneRequests(s.requests),
+				h:        latency.histogram(),
+			}
+		}
+		var (
+			nextWorker atomic.Int32
+			failed     atomic.Bool
+		)
+		b.ResetTimer()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			w := workers[nextWorker.Add(1)-1]
+			rnd, c, requests, h := w.rnd, w.c, w.requests, w.h
+			for pb.Next() {
+				req := requests[rnd.Intn(requestsLen)]
+				// the clock is read before and after the request, the goroutine can be descheduled between two requests
+				var start time.Time
+				if h != nil {
+					start = time.Now()
+				}
+				status := c.do(req)
+				if h != nil {
+					h.Record(int64(time.Since(start)))
+				}
+				if !s.accept(status) {
+					// FailNow must be called by the benchmark goroutine, the benchmark is failed once RunParallel returns
+					failed.Store(true)
+					b.Errorf("got %d for %s %s", status, req.Method, req.URL.Path)
+					return
+				}
+			}
+		})
+		b.StopTimer()
+		if failed.Load() {
+			b.FailNow()
+		}
+		for _, w := range workers {
+			latency.merge(w.h)
+		}
+		latency.report(b)
+	})
+}
+
+// worker is the state of a goroutine of a concurrent benchmark
+type worker struct {
+	rnd      *rand.Rand
+	c        client
+	requests []*http.Request
+	h        *hdr.Histogram
+}
+
+// cloneRequests copies the requests for a goroutine of a concurrent benchmark,
+// because some frameworks, like pat, modify the request they serve
+func cloneRequests(requests []*http.Request) []*http.Request {
+	clones := make([]*http.Request, len(requests))
+	for i, r := range requests {
+		clones[i] = r.Clone(r.Context())
+	}
+	return clones
+}
+
+// latencyRecorder merges the latency histograms of the goroutines of a benchmark run.
+// The histograms are nil when the -latency flag is false.
+type latencyRecorder struct {
+	mu     sync.Mutex
+	merged *hdr.Histogram
+	always bool
+}
+
+// histogram returns the histogram that a goroutine records into, or nil if the latency is not recorded
+func (l *latencyRecorder) histogram() *hdr.Histogram {
+	if !*recordLatency && !l.always {
+		return nil
+	}
+	return hdr.New()
+}
+
+func (l *latencyRecorder) merge(h *hdr.Histogram) {
+	if h == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.merged == nil {
+		l.merged = hdr.New()
+	}
+	l.merged.Merge(h)
+}
+
+// recordSince records the time elapsed since the end of the previous request and returns the current time.
+// Reading the clock once per request, instead of before and after it, halves the overhead of the measure, but it is
+// only accurate when the goroutine is not descheduled between the requests, as in the single-threaded benchmarks.
+func recordSince(h *hdr.Histogram, last time.Time) time.Time {
+	now := time.Now()
+	h.Record(int64(now.Sub(last)))
+	return now
+}
+
+// report adds the latency percentiles and the max latency, in nanoseconds, to the benchmark result
+func (l *latencyRecorder) report(b *testing.B) {
+	if l.merged == nil || l.merged.Count() == 0 {
+		return
+	}
+	b.ReportMetric(float64(l.merged.ValueAt(50)), "p50-ns")
+	b.ReportMetric(float64(l.merged.ValueAt(90)), "p90-ns")
+	b.ReportMetric(float64(l.merged.ValueAt(99)), "p99-ns")
+	b.ReportMetric(float64(l.merged.ValueAt(99.9)), "p99.9-ns")
+	b.ReportMetric(float64(l.merged.Max()), "max-ns")
+}
+
+func benchmarkRoutes(b *testing.B, set *RouteSet, handler Handler) {
+	benchmarkScenario(b, routesScenario(set, handler, false))
+}
+
+func benchmarkRoutesConcurrent(b *testing.B, set *RouteSet, handler Handler) {
+	benchmarkScenarioConcurrent(b, routesScenario(set, handler, true))
+}
+
+func Benchmark_Static(b *testing.B) {
+	benchmarkRoutes(b, staticRouteSet, HandlerOK)
+}
+
+func Benchmark_Static_Concurrent(b *testing.B) {
+	benchmarkRoutesConcurrent(b, staticRouteSet, HandlerOK)
+}
+
+func Benchmark_VarCapture(b *testing.B) {
+	benchmarkRoutes(b, varCaptureRouteSet, HandlerOK)
+}
+
+func Benchmark_VarCapture_Concurrent(b *testing.B) {
+	benchmarkRoutesConcurrent(b, varCaptureRouteSet, HandlerOK)
+}
+
+func Benchmark_VarCapture_ParamRead(b *testing.B) {
+	benchmarkRoutes(b, varCaptureRouteSet, HandlerParamRead)
+}
+
+func Benchmark_VarCapture_ParamRead_Concurrent(b *testing.B) {
+	benchmarkRoutesConcurrent(b, varCaptureRouteSet, HandlerParamRead)
+}
+
+func Benchmark_Wildcard(b *testing.B) {
+	benchmarkRoutes(b, wildcardRouteSet, HandlerOK)
+}
+
+func Benchmark_Wildcard_Concurrent(b *testing.B) {
+	benchmarkRoutesConcurrent(b, wildcardRouteSet, HandlerOK)
+}
+
+func Benchmark_Wildcard_ParamRead(b *testing.B) {
+	benchmarkRoutes(b, wildcardRouteSet, HandlerParamRead)
+}
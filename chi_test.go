@@ -0,0 +1,77 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+func init() {
+	registerFramework("chi", newChiAdapter)
+}
+
+type chiAdapter struct {
+	r *chi.Mux
+}
+
+func newChiAdapter() FrameworkAdapter {
+	return &chiAdapter{r: chi.NewRouter()}
+}
+
+func (a *chiAdapter) Name() string {
+	return "chi"
+}
+
+func (a *chiAdapter) Version() string {
+	return moduleVersion("github.com/go-chi/chi/v5")
+}
+
+// chiSyntax keeps the {name} and {name:regexp} syntax and converts the *name catch-all variable into *,
+// because chi doesn't name it
+var chiSyntax = pathSyntax{
+	param: bracesParam,
+	constrained: func(s *patternSegment) string {
+		return "{" + s.Value + ":" + s.anchoredPattern() + "}"
+	},
+	catchAll: func(string) string {
+		return "*"
+	},
+}
+
+func (a *chiAdapter) TranslatePath(path string) string {
+	return routePattern(path).format(chiSyntax)
+}
+
+func (a *chiAdapter) Use(m Middleware) {
+	a.r.Use(httpMiddleware(m))
+}
+
+func (a *chiAdapter) Register(route *Route, path string, h Handler) (err error) {
+	defer recoverRegistration(&err)
+	a.r.MethodFunc(route.Method, path, chiHandler(route, h))
+	return nil
+}
+
+func (a *chiAdapter) Build() http.Handler {
+	return a.r
+}
+
+func chiHandler(route *Route, h Handler) http.HandlerFunc {
+	switch h {
+	case HandlerRouteInfo:
+		return func(w http.ResponseWriter, r *http.Request) {
+			writePlainText(w, routeInfo(route, renameCatchAll(route, "*", func(name string) string {
+				return chi.URLParam(r, name)
+			})))
+		}
+	case HandlerParamRead:
+		params := route.NativeParams("*")
+		return func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.RouteContext(r.Context())
+			writePlainText(w, joinParams(params, rctx.URLParam))
+		}
+	default:
+		return func(w http.ResponseWriter, r *http.Request) {
+			writePlainText(w, "ok")
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package router
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	selectedFrameworks = flag.String("frameworks", "", "comma separated names of the benchmarked frameworks, all of them if empty")
+	listFrameworks     = flag.Bool("frameworks.list", false, "print the names of the frameworks and exit")
+)
+
+// client drives a router from a single goroutine and reuses the response between the requests.
+// It hides the server model of the framework, so the same benchmark loops run against net/http and fasthttp routers.
+type client interface {
+	// do serves the request and returns the response status code
+	do(r *http.Request) int
+	// body returns the body of the last response
+	body() []byte
+	// header returns the value of a header of the last response
+	header(name string) string
+}
+
+// benchmarkedFrameworks returns the names of the frameworks selected by the -frameworks flag, the net/http frameworks
+// followed by the fasthttp ones, which are labelled with the fasthttp/ prefix
+func benchmarkedFrameworks() []string {
+	if *selectedFrameworks == "" {
+		return allFrameworks()
+	}
+	var names []string
+	for _, name := range allFrameworks() {
+		for _, selected := range strings.Split(*selectedFrameworks, ",") {
+			if name == strings.TrimSpace(selected) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+func allFrameworks() []string {
+	names := frameworkNames()
+	for _, name := range fastHTTPFrameworkNames() {
+		names = append(names, fastHTTPPrefix+name)
+	}
+	return names
+}
+
+// checkSelectedFrameworks returns an error if the -frameworks flag names an unknown framework
+func checkSelectedFrameworks() error {
+	if *selectedFrameworks == "" {
+		return nil
+	}
+	known := map[string]bool{}
+	for _, name := range allFrameworks() {
+		known[name] = true
+	}
+	for _, selected := range strings.Split(*selectedFrameworks, ",") {
+		if !known[strings.TrimSpace(selected)] {
+			return fmt.Errorf("unknown framework %q in -frameworks, the frameworks are: %s", selected, strings.Join(allFrameworks(), ","))
+		}
+	}
+	return nil
+}
+
+// frameworkVersion returns the module version of the named framework.
+// The name of a fasthttp framework must have the fasthttp/ prefix.
+func frameworkVersion(name string) string {
+	if fastHTTPName, found := strings.CutPrefix(name, fastHTTPPrefix); found {
+		return fastHTTPFrameworks[fastHTTPName]().Version()
+	}
+	return frameworks[name]().Version()
+}
+
+// newClientFactory registers the middlewares and the routes into the named framework and returns a function that
+// creates clients for the built router. The name of a fasthttp framework must have the fasthttp/ prefix.
+func newClientFactory(name string, routes []*Route, handler Handler, middlewares ...Middleware) (func() client, error) {
+	if fastHTTPName, found := strings.CutPrefix(name, fastHTTPPrefix); found {
+		_, h, err := newFastHTTPRouter(fastHTTPName, routes, handler, middlewares...)
+		if err != nil {
+			return nil, err
+		}
+		return func() client {
+			return &fastHTTPClient{handler: h}
+		}, nil
+	}
+
+	_, h, err := newRouter(name, routes, handler, middlewares...)
+	if err != nil {
+		return nil, err
+	}
+	return func() client {
+		return &netHTTPClient{handler: h, w: newResponseWriter()}
+	}, nil
+}
+
+type netHTTPClient struct {
+	handler http.Handler
+	w       *responseWriter
+}
+
+func (c *netHTTPClient) do(r *http.Request) int {
+	c.w.reset()
+	c.handler.ServeHTTP(c.w, r)
+	return c.w.status
+}
+
+func (c *netHTTPClient) body() []byte {
+	return c.w.buf.Bytes()
+}
+
+// header returns the value of a response header. The headers are not reset between the requests, so it can only be
+// trusted for the first response of the client.
+func (c *netHTTPClient) header(name string) string {
+	return c.w.header.Get(name)
+}
+
+// responseWriter is a minimal http.ResponseWriter that, unlike httptest.ResponseRecorder, can be reused:
+// the status code is reset between the requests
+type responseWriter struct {
+	header http.Header
+	status int
+	buf    bytes.Buffer
+}
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{header: make(http.Header)}
+}
+
+// reset prepares the writer for the next request.
+// The headers are not reset because the header writing produces heap allocations and, it is out of the benchmark scope.
+func (w *responseWriter) reset() {
+	w.status = 0
+	w.buf.Reset()
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.buf.Write(b)
+}
+
+func (w *responseWriter) WriteString(s string) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.buf.WriteString(s)
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
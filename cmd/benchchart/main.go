@@ -0,0 +1,288 @@
+// Command benchchart renders the benchmark results as the bar charts published in docs/img and regenerates the results
+// section of the README, so the published comparison can be reproduced with one command:
+//
+//	go test -run '^$' -bench '^Benchmark_(Static|VarCapture)$' -benchmem -args -latency=false > single.txt
+//	go test -run '^$' -bench '^Benchmark_(Static|VarCapture)_Concurrent$' -benchmem -cpu 4 -args -latency=false > multi.txt
+//	go run ./cmd/benchchart -readme README.md single.txt multi.txt
+//
+// The results are read from the files given as arguments, the benchmark output or the JSON written by benchexport,
+// or from the standard input. When a scenario was executed several times, with -count, the mean of the runs is charted.
+// A scenario executed with several GOMAXPROCS values, with -cpu, has one chart per value, whose file name ends with
+// -gomaxprocs-N.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/ixtendio/gofrebench/internal/chart"
+	"github.com/ixtendio/gofrebench/internal/results"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// publishedChart is a chart linked from the README
+type publishedChart struct {
+	scenario string
+	file     string
+	title    string
+	// multiThread reports whether the chart is meant to show results executed with GOMAXPROCS > 1
+	multiThread bool
+	// procs is the GOMAXPROCS of the charted results
+	procs int
+}
+
+var publishedCharts = []publishedChart{
+	{scenario: "VarCapture_Concurrent", file: "performance-path-capture-variables-multi-thread", title: "Performance - Path Capture Variables (multi-thread)", multiThread: true},
+	{scenario: "VarCapture", file: "performance-path-capture-variables-single-thread", title: "Performance - Path Capture Variables (single-thread)"},
+	{scenario: "Static", file: "performance-static-resources-single-thread", title: "Performance - Static Resources (single-thread)"},
+	{scenario: "Static_Concurrent", file: "performance-static-resources-multi-thread", title: "Performance - Static Resources (multi-thread)", multiThread: true},
+}
+
+const (
+	readmeBegin = "<!-- benchchart:begin -->"
+	readmeEnd   = "<!-- benchchart:end -->"
+)
+
+func main() {
+	outDir := flag.String("o", "docs/img", "directory of the rendered charts")
+	formats := flag.String("format", "png", "comma separated formats of the charts: png, svg")
+	all := flag.Bool("all", false, "render a chart for every scenario of the results, not only the published ones")
+	readme := flag.String("readme", "", "regenerate the results section of the README file, delimited by the "+readmeBegin+" and "+readmeEnd+" lines")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("benchchart: ")
+
+	res, err := results.ReadFiles(flag.Args()...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	scenarios := groupByScenario(res)
+	if len(scenarios) == 0 {
+		log.Fatal("no benchmark result found in the input")
+	}
+
+	var rendered []publishedChart
+	for _, pc := range chartsFor(scenarios, *all) {
+		if pc.multiThread && pc.procs == 1 {
+			log.Printf("warning: %s was executed with GOMAXPROCS=1, the %s chart is not multi-thread", pc.scenario, pc.file)
+		}
+		c := barChart(pc.title, scenarios[scenarioKey{pc.scenario, pc.procs}])
+		for _, format := range strings.Split(*formats, ",") {
+			path := filepath.Join(*outDir, pc.file+"."+strings.TrimSpace(format))
+			if err := renderFile(path, c, strings.TrimSpace(format)); err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("rendered %s", path)
+		}
+		rendered = append(rendered, pc)
+	}
+
+	if *readme != "" {
+		linkFormat := strings.TrimSpace(strings.Split(*formats, ",")[0])
+		if err := updateReadme(*readme, *outDir, linkFormat, rendered, scenarios); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// scenarioKey identifies the results of a scenario executed with a GOMAXPROCS value
+type scenarioKey struct {
+	scenario string
+	procs    int
+}
+
+// scenarioResults contains the results of a scenario executed with a GOMAXPROCS value, one per framework in the order
+// of the output, the repeated runs of a framework being merged by mean
+type scenarioResults struct {
+	frameworks []string
+	results    map[string]*results.Result
+	runs       map[string]int
+	// iterations is the sum of the iterations of the runs, per framework
+	iterations map[string]int64
+}
+
+func groupByScenario(res []*results.Result) map[scenarioKey]*scenarioResults {
+	scenarios := map[scenarioKey]*scenarioResults{}
+	for _, r := range res {
+		k := scenarioKey{r.Scenario, r.GOMAXPROCS}
+		s := scenarios[k]
+		if s == nil {
+			s = &scenarioResults{results: map[string]*results.Result{}, runs: map[string]int{}, iterations: map[string]int64{}}
+			scenarios[k] = s
+		}
+		s.runs[r.Framework]++
+		s.iterations[r.Framework] += r.Iterations
+		mean, found := s.results[r.Framework]
+		if !found {
+			copied := *r
+			copied.Metrics = map[string]float64{}
+			for unit, v := range r.Metrics {
+				copied.Metrics[unit] = v
+			}
+			s.frameworks = append(s.frameworks, r.Framework)
+			s.results[r.Framework] = &copied
+			continue
+		}
+		// incremental mean of the runs
+		n := float64(s.runs[r.Framework])
+		for unit, v := range r.Metrics {
+			mean.Metrics[unit] += (v - mean.Metrics[unit]) / n
+		}
+	}
+	// the iterations are summed and divided once, an incremental integer mean would truncate at every run
+	for _, s := range scenarios {
+		for name, r := range s.results {
+			r.Iterations = s.iterations[name] / int64(s.runs[name])
+		}
+	}
+	return scenarios
+}
+
+// chartsFor returns the charts to render: the published ones found in the results and, if all is true,
+// one chart for every other scenario. A scenario executed with several GOMAXPROCS values has one chart per value.
+func chartsFor(scenarios map[scenarioKey]*scenarioResults, all bool) []publishedChart {
+	procs := map[string][]int{}
+	for k := range scenarios {
+		procs[k.scenario] = append(procs[k.scenario], k.procs)
+	}
+	var charts []publishedChart
+	published := map[string]bool{}
+	for _, pc := range publishedCharts {
+		published[pc.scenario] = true
+		charts = append(charts, chartsPerProcs(pc, procs[pc.scenario])...)
+	}
+	if !all {
+		return charts
+	}
+	var others []string
+	for name := range procs {
+		if !published[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		pc := publishedChart{
+			scenario: name,
+			file:     "performance-" + strings.ToLower(strings.ReplaceAll(name, "_", "-")),
+			title:    "Performance - " + strings.ReplaceAll(name, "_", " "),
+		}
+		charts = append(charts, chartsPerProcs(pc, procs[name])...)
+	}
+	return charts
+}
+
+// chartsPerProcs returns the chart of every GOMAXPROCS value, the file and the title get the value when there are
+// several ones
+func chartsPerProcs(pc publishedChart, procs []int) []publishedChart {
+	sort.Ints(procs)
+	charts := make([]publishedChart, len(procs))
+	for i, p := range procs {
+		charts[i] = pc
+		charts[i].procs = p
+		if len(procs) > 1 {
+			charts[i].file = fmt.Sprintf("%s-gomaxprocs-%d", pc.file, p)
+			charts[i].title = fmt.Sprintf("%s GOMAXPROCS=%d", pc.title, p)
+		}
+	}
+	return charts
+}
+
+// barChart groups the bars by performance parameter, with one bar per framework, like the original charts
+func barChart(title string, s *scenarioResults) *chart.BarChart {
+	c := &chart.BarChart{
+		Title:  title,
+		XLabel: "Performance Parameters",
+		Groups: []string{"Total\nRequests", "ns/op", "B/op", "allocs/op"},
+	}
+	for _, name := range s.frameworks {
+		r := s.results[name]
+		c.Series = append(c.Series, chart.Series{
+			Name:   name,
+			Values: []float64{float64(r.Iterations), r.Metrics["ns/op"], r.Metrics["B/op"], r.Metrics["allocs/op"]},
+		})
+	}
+	return c
+}
+
+func renderFile(path string, c *chart.BarChart, format string) error {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "png":
+		err = c.PNG(&buf)
+	case "svg":
+		err = c.SVG(&buf)
+	default:
+		return fmt.Errorf("unsupported chart format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// updateReadme replaces the lines between the README markers by the links to the charts in the given format,
+// the environment of the run and a table of the charted results
+func updateReadme(path, imgDir, format string, charts []publishedChart, scenarios map[scenarioKey]*scenarioResults) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	re := regexp.MustCompile(`(?s)` + regexp.QuoteMeta(readmeBegin) + `\n.*?` + regexp.QuoteMeta(readmeEnd))
+	if !re.Match(content) {
+		return fmt.Errorf("%s: the %s and %s lines are missing", path, readmeBegin, readmeEnd)
+	}
+
+	var section bytes.Buffer
+	section.WriteString(readmeBegin + "\n")
+	writeReadmeSection(&section, filepath.ToSlash(relativeTo(path, imgDir)), format, charts, scenarios)
+	section.WriteString(readmeEnd)
+	content = re.ReplaceAllLiteral(content, section.Bytes())
+	return os.WriteFile(path, content, 0o644)
+}
+
+func writeReadmeSection(w io.Writer, imgDir, format string, charts []publishedChart, scenarios map[scenarioKey]*scenarioResults) {
+	for _, pc := range charts {
+		fmt.Fprintf(w, "![%s](%s/%s.%s)\n", pc.title, imgDir, pc.file, format)
+	}
+	if len(charts) == 0 {
+		return
+	}
+
+	first := scenarios[scenarioKey{charts[0].scenario, charts[0].procs}]
+	env := first.results[first.frameworks[0]]
+	fmt.Fprintf(w, "\nThe benchmark was executed on `%s %s` with `%s`.\n\n", env.OS, env.CPU, env.GoVersion)
+	fmt.Fprintln(w, "| Scenario | GOMAXPROCS | Framework | Version | Route set | ns/op | B/op | allocs/op |")
+	fmt.Fprintln(w, "|----------|-----------:|-----------|---------|-----------|------:|-----:|----------:|")
+	for _, pc := range charts {
+		s := scenarios[scenarioKey{pc.scenario, pc.procs}]
+		for _, name := range s.frameworks {
+			r := s.results[name]
+			fmt.Fprintf(w, "| %s | %d | %s | %s | %s | %s | %s | %s |\n", pc.scenario, pc.procs, name, r.FrameworkVersion, r.RouteSet,
+				formatMetric(r.Metrics["ns/op"]), formatMetric(r.Metrics["B/op"]), formatMetric(r.Metrics["allocs/op"]))
+		}
+	}
+}
+
+// relativeTo returns the directory relative to the directory of the file, so the README links work from the file
+func relativeTo(file, dir string) string {
+	rel, err := filepath.Rel(filepath.Dir(file), dir)
+	if err != nil {
+		return dir
+	}
+	return rel
+}
+
+func formatMetric(v float64) string {
+	if v >= 100 || v == float64(int64(v)) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
@@ -0,0 +1,219 @@
+// Command benchcmp compares two benchmark runs, for example before and after a GoFre upgrade, and fails when GoFre
+// got significantly slower.
+//
+// Usage:
+//
+//	go test -run '^$' -bench . -benchmem -count 10 > old.txt
+//	# upgrade GoFre
+//	go test -run '^$' -bench . -benchmem -count 10 > new.txt
+//	go run ./cmd/benchcmp old.txt new.txt
+//
+// Every benchmark found in both runs, identified by its scenario, framework and GOMAXPROCS, is compared metric by
+// metric, like benchstat does: the samples of the repeated runs (-count) are summarized by their median and the Mann-Whitney U test tells whether the difference is
+// significant. A non-significant delta is printed as ~. The files can also be the JSON written by benchexport.
+//
+// The exit code is 1 when a metric of the checked framework (-framework) regressed significantly by more than the
+// threshold, 2 when the comparison could not be made.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ixtendio/gofrebench/internal/results"
+	"github.com/ixtendio/gofrebench/internal/stats"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// higherIsBetter lists the units for which an increase is an improvement, for all the others it's a regression
+var higherIsBetter = map[string]bool{"req/s": true}
+
+func main() {
+	alpha := flag.Float64("alpha", 0.05, "significance level of the Mann-Whitney U test")
+	threshold := flag.Float64("threshold", 5, "regression, in percent, above which the exit code is 1")
+	framework := flag.String("framework", "gofre", "framework whose regressions fail the comparison, empty for none")
+	metrics := flag.String("metrics", "ns/op,B/op,allocs/op", "comma separated units of the compared metrics")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] old new\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("benchcmp: ")
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	old, err := readSamples(flag.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+	cur, err := readSamples(flag.Arg(1))
+	if err != nil {
+		fatal(err)
+	}
+	units := strings.Split(*metrics, ",")
+	comparisons := compare(old, cur, units, *alpha)
+	if len(comparisons) == 0 {
+		fatal(fmt.Errorf("no benchmark found in both %s and %s", flag.Arg(0), flag.Arg(1)))
+	}
+
+	if *framework != "" {
+		printVersions(os.Stdout, *framework, old, cur)
+	}
+	printComparisons(os.Stdout, comparisons)
+
+	regressions := 0
+	for _, c := range comparisons {
+		if c.framework == *framework && c.significant && c.regression() > *threshold {
+			if regressions == 0 {
+				fmt.Println()
+			}
+			fmt.Printf("REGRESSION: %s %s %s %+.2f%% (p=%.3f n=%d+%d)\n", c.framework, c.name(), c.unit, c.delta, c.p, c.old.N, c.new.N)
+			regressions++
+		}
+	}
+	if regressions > 0 {
+		os.Exit(1)
+	}
+}
+
+func fatal(err error) {
+	log.Print(err)
+	os.Exit(2)
+}
+
+// key identifies the samples of a framework in a scenario run with a GOMAXPROCS value, like the full benchmark name
+type key struct {
+	scenario  string
+	framework string
+	procs     int
+}
+
+// name returns the scenario with the -N suffix of the benchmark name when GOMAXPROCS is not 1
+func (k key) name() string {
+	if k.procs == 1 {
+		return k.scenario
+	}
+	return fmt.Sprintf("%s-%d", k.scenario, k.procs)
+}
+
+// run contains the results of a run grouped by benchmark, in the order of the output
+type run struct {
+	keys    []key
+	samples map[key][]*results.Result
+}
+
+func readSamples(path string) (*run, error) {
+	res, err := results.ReadFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%s: no benchmark result found", path)
+	}
+	r := &run{samples: map[key][]*results.Result{}}
+	for _, result := range res {
+		k := key{scenario: result.Scenario, framework: result.Framework, procs: result.GOMAXPROCS}
+		if _, found := r.samples[k]; !found {
+			r.keys = append(r.keys, k)
+		}
+		r.samples[k] = append(r.samples[k], result)
+	}
+	return r, nil
+}
+
+func (r *run) values(k key, unit string) []float64 {
+	var values []float64
+	for _, result := range r.samples[k] {
+		if v, found := result.Metrics[unit]; found {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func (r *run) version(framework string) string {
+	for _, k := range r.keys {
+		if k.framework == framework {
+			return r.samples[k][0].FrameworkVersion
+		}
+	}
+	return ""
+}
+
+// comparison is the comparison of a metric of a framework in a scenario
+type comparison struct {
+	key
+	unit        string
+	old, new    stats.Summary
+	delta       float64
+	p           float64
+	significant bool
+}
+
+// regression returns the delta, in percent, oriented so that a positive value is a regression
+func (c *comparison) regression() float64 {
+	if higherIsBetter[c.unit] {
+		return -c.delta
+	}
+	return c.delta
+}
+
+func compare(old, cur *run, units []string, alpha float64) []*comparison {
+	var comparisons []*comparison
+	for _, k := range old.keys {
+		if _, found := cur.samples[k]; !found {
+			continue
+		}
+		for _, unit := range units {
+			unit = strings.TrimSpace(unit)
+			x, y := old.values(k, unit), cur.values(k, unit)
+			if len(x) == 0 || len(y) == 0 {
+				continue
+			}
+			c := &comparison{key: k, unit: unit, old: stats.Summarize(x), new: stats.Summarize(y)}
+			switch {
+			case c.old.Median != 0:
+				c.delta = (c.new.Median - c.old.Median) / c.old.Median * 100
+			case c.new.Median > 0:
+				// from nothing to something, like 0 -> 1 allocs/op, is an infinite increase
+				c.delta = math.Inf(1)
+			}
+			c.p = stats.MannWhitneyUTest(x, y)
+			c.significant = c.p < alpha && c.old.Median != c.new.Median
+			comparisons = append(comparisons, c)
+		}
+	}
+	return comparisons
+}
+
+func printVersions(w io.Writer, framework string, old, cur *run) {
+	oldVersion, newVersion := old.version(framework), cur.version(framework)
+	if oldVersion != "" || newVersion != "" {
+		fmt.Fprintf(w, "%s: %s -> %s\n\n", framework, oldVersion, newVersion)
+	}
+}
+
+func printComparisons(w io.Writer, comparisons []*comparison) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "scenario\tframework\tunit\told\tnew\tdelta\tp\t")
+	for _, c := range comparisons {
+		delta := "~"
+		if c.significant {
+			delta = fmt.Sprintf("%+.2f%%", c.delta)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\tp=%.3f n=%d+%d\t\n",
+			c.name(), c.framework, c.unit, formatSummary(c.old), formatSummary(c.new), delta, c.p, c.old.N, c.new.N)
+	}
+	tw.Flush()
+}
+
+func formatSummary(s stats.Summary) string {
+	return fmt.Sprintf("%.4g ±%.0f%%", s.Median, s.Spread*100)
+}
@@ -0,0 +1,54 @@
+// Command benchexport converts the output of the benchmarks into JSON and CSV files.
+//
+// Usage:
+//
+//	go test -run '^$' -bench . -benchmem | tee bench.txt
+//	go run ./cmd/benchexport -json results.json -csv results.csv bench.txt
+//
+// The benchmark output is read from the files given as arguments, or from the standard input. The files with the .json
+// extension are read as previously exported results, so several runs can be merged into one export.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ixtendio/gofrebench/internal/results"
+	"io"
+	"log"
+	"os"
+)
+
+func main() {
+	jsonPath := flag.String("json", "", "write the results as JSON into the file, - for the standard output")
+	csvPath := flag.String("csv", "", "write the results as CSV into the file, - for the standard output")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-json file] [-csv file] [bench output files]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("benchexport: ")
+
+	if *jsonPath == "" && *csvPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	res, err := results.ReadFiles(flag.Args()...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(res) == 0 {
+		log.Fatal("no benchmark result found in the input")
+	}
+	if *jsonPath != "" {
+		if err := results.WriteFile(*jsonPath, func(w io.Writer) error { return results.WriteJSON(w, res) }); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *csvPath != "" {
+		if err := results.WriteFile(*csvPath, func(w io.Writer) error { return results.WriteCSV(w, res) }); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
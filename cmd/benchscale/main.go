@@ -0,0 +1,50 @@
+// Command benchscale prints how the throughput of the frameworks scales with GOMAXPROCS, from the results of the
+// concurrent scenarios run with several -cpu values.
+//
+// Usage:
+//
+//	go test -run '^$' -bench '_Concurrent$' -cpu 1,2,4,8 -parallelism 1 | tee bench.txt
+//	go run ./cmd/benchscale bench.txt
+//
+// For every scenario, the table has one row per framework and one column per GOMAXPROCS value, with the throughput and
+// the scaling efficiency: the speedup over the smallest GOMAXPROCS divided by the ratio of the procs.
+// The files can also be the JSON written by benchexport, the standard input is read if there are no files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ixtendio/gofrebench/internal/results"
+	"github.com/ixtendio/gofrebench/internal/scaling"
+	"io"
+	"log"
+	"os"
+)
+
+func main() {
+	csvPath := flag.String("csv", "", "also write the curves as CSV into the file, one row per GOMAXPROCS value")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-csv file] [bench output files]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("benchscale: ")
+
+	res, err := results.ReadFiles(flag.Args()...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	curves := scaling.Curves(res)
+	if len(curves) == 0 {
+		log.Fatal("no benchmark result found in the input")
+	}
+	if err := scaling.WriteTable(os.Stdout, curves); err != nil {
+		log.Fatal(err)
+	}
+	if *csvPath != "" {
+		if err := results.WriteFile(*csvPath, func(w io.Writer) error { return scaling.WriteCSV(w, curves) }); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
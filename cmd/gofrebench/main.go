@@ -0,0 +1,290 @@
+// Command gofrebench runs the benchmarks of a selection of frameworks and scenarios, each framework of each scenario
+// in its own process, so the garbage produced and the heap grown by a framework don't slow down the next one.
+//
+// Usage:
+//
+//	go run ./cmd/gofrebench -list
+//	go run ./cmd/gofrebench -frameworks gofre,gin -scenarios static,varcapture -threads 1,4,16 -duration 10s -json results.json
+//	go run ./cmd/gofrebench -scaling -frameworks gofre,gin
+//
+// The command compiles the test binary of the benchmarks once, then runs it for every selected scenario and framework.
+// The benchmark output is printed on the standard output and can be saved with -o, the results can be exported with
+// -json and -csv like benchexport does. The arguments after -- are passed to the test binary, for example
+// -- -latency=false -loopback.concurrency=64.
+//
+// With -scaling, the concurrent scenarios are run across a GOMAXPROCS sweep, the powers of two up to the number of CPUs
+// unless -threads is set, with one goroutine per GOMAXPROCS unless -goroutines is set, and the throughput and the
+// scaling efficiency of every framework are printed at the end.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/ixtendio/gofrebench/internal/results"
+	"github.com/ixtendio/gofrebench/internal/scaling"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	list := flag.Bool("list", false, "print the frameworks and the scenarios and exit")
+	frameworksFlag := flag.String("frameworks", "", "comma separated frameworks to benchmark, all of them if empty")
+	scenariosFlag := flag.String("scenarios", "", "comma separated scenarios to run, case insensitive, all of them if empty")
+	threads := flag.String("threads", "", "comma separated GOMAXPROCS values each benchmark is run with, the current one if empty")
+	duration := flag.String("duration", "1s", "run time of each benchmark, or the number of iterations as Nx")
+	count := flag.Int("count", 1, "number of runs of each benchmark, at least 5 to compare the results with benchcmp")
+	scalingMode := flag.Bool("scaling", false, "run the concurrent scenarios across a GOMAXPROCS sweep and print the scaling efficiency")
+	goroutines := flag.Int("goroutines", 0, "goroutines per GOMAXPROCS of the concurrent scenarios, 0 means one per request, or one with -scaling")
+	pkg := flag.String("pkg", "github.com/ixtendio/gofrebench", "package of the benchmarks")
+	outPath := flag.String("o", "", "write the benchmark output into the file")
+	jsonPath := flag.String("json", "", "write the results as JSON into the file, - for the standard output")
+	csvPath := flag.String("csv", "", "write the results as CSV into the file, - for the standard output")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [-- test binary flags]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("gofrebench: ")
+
+	dir, err := os.MkdirTemp("", "gofrebench")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	bin, err := buildTestBinary(*pkg, dir)
+	if err != nil {
+		fatal(dir, err)
+	}
+
+	frameworks, err := bin.output("-frameworks.list")
+	if err != nil {
+		fatal(dir, err)
+	}
+	benchmarks, err := bin.output("-test.list", "^Benchmark")
+	if err != nil {
+		fatal(dir, err)
+	}
+	var scenarios []string
+	for _, benchmark := range benchmarks {
+		scenarios = append(scenarios, strings.TrimPrefix(strings.TrimPrefix(benchmark, "Benchmark"), "_"))
+	}
+	if *list {
+		fmt.Println("frameworks:")
+		for _, name := range frameworks {
+			fmt.Println("  " + name)
+		}
+		fmt.Println("scenarios:")
+		for _, name := range scenarios {
+			fmt.Println("  " + name)
+		}
+		return
+	}
+
+	if frameworks, err = selectNames("framework", frameworks, *frameworksFlag, false); err != nil {
+		fatal(dir, err)
+	}
+	if *scalingMode && *scenariosFlag == "" {
+		scenarios = concurrentScenarios(scenarios)
+	}
+	if scenarios, err = selectNames("scenario", scenarios, *scenariosFlag, true); err != nil {
+		fatal(dir, err)
+	}
+	if *scalingMode {
+		if *threads == "" {
+			*threads = procsSweep(runtime.NumCPU())
+		}
+		if *goroutines == 0 {
+			*goroutines = 1
+		}
+	}
+
+	// the runs are already isolated, the benchmarks don't need to start a child process per framework
+	args := []string{"-test.run", "^$", "-inprocess", "-test.benchmem", "-test.benchtime", *duration, "-test.count", fmt.Sprint(*count)}
+	if *threads != "" {
+		args = append(args, "-test.cpu", *threads)
+	}
+	if *goroutines > 0 {
+		args = append(args, "-parallelism", strconv.Itoa(*goroutines))
+	}
+	var output bytes.Buffer
+	failed := 0
+	runs, total := 0, len(scenarios)*len(frameworks)
+	for _, scenario := range scenarios {
+		for _, framework := range frameworks {
+			runs++
+			log.Printf("[%d/%d] %s %s", runs, total, scenario, framework)
+			runArgs := append(append([]string{}, args...), "-test.bench", "^Benchmark_"+scenario+"$", "-frameworks", framework)
+			var runOutput bytes.Buffer
+			err := bin.run(&runOutput, append(runArgs, flag.Args()...)...)
+			printResults(os.Stdout, runOutput.Bytes())
+			output.Write(runOutput.Bytes())
+			if err != nil {
+				log.Printf("%s %s: %v", scenario, framework, err)
+				failed++
+			}
+		}
+	}
+
+	if *outPath != "" {
+		if err := results.WriteFile(*outPath, func(w io.Writer) error { _, err := w.Write(output.Bytes()); return err }); err != nil {
+			fatal(dir, err)
+		}
+	}
+	res, err := results.Parse(&output)
+	if err != nil {
+		fatal(dir, err)
+	}
+	if *jsonPath != "" {
+		if err := results.WriteFile(*jsonPath, func(w io.Writer) error { return results.WriteJSON(w, res) }); err != nil {
+			fatal(dir, err)
+		}
+	}
+	if *csvPath != "" {
+		if err := results.WriteFile(*csvPath, func(w io.Writer) error { return results.WriteCSV(w, res) }); err != nil {
+			fatal(dir, err)
+		}
+	}
+	if *scalingMode {
+		fmt.Println()
+		if err := scaling.WriteTable(os.Stdout, scaling.Curves(res)); err != nil {
+			fatal(dir, err)
+		}
+	}
+	if failed > 0 {
+		fatal(dir, fmt.Errorf("%d of %d runs failed", failed, total))
+	}
+}
+
+// concurrentScenarios returns the scenarios served by several goroutines
+func concurrentScenarios(scenarios []string) []string {
+	var concurrent []string
+	for _, s := range scenarios {
+		if strings.HasSuffix(s, "_Concurrent") {
+			concurrent = append(concurrent, s)
+		}
+	}
+	return concurrent
+}
+
+// procsSweep returns the powers of two lower than maxProcs followed by maxProcs, as a -test.cpu list
+func procsSweep(maxProcs int) string {
+	var procs []string
+	for p := 1; p < maxProcs; p *= 2 {
+		procs = append(procs, strconv.Itoa(p))
+	}
+	return strings.Join(append(procs, strconv.Itoa(maxProcs)), ",")
+}
+
+// fatal logs the error and exits, after removing the directory of the test binary which defer would not remove
+func fatal(dir string, err error) {
+	os.RemoveAll(dir)
+	log.Fatal(err)
+}
+
+// testBinary is the compiled test binary of the benchmarks, run from the package directory
+type testBinary struct {
+	path string
+	dir  string
+}
+
+func buildTestBinary(pkg, tmpDir string) (*testBinary, error) {
+	out, err := exec.Command("go", "list", "-f", "{{.Dir}}", pkg).Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list %s: %w", pkg, exitError(err))
+	}
+	bin := &testBinary{path: filepath.Join(tmpDir, "bench.test"), dir: strings.TrimSpace(string(out))}
+	cmd := exec.Command("go", "test", "-c", "-o", bin.path, pkg)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go test -c %s: %w", pkg, err)
+	}
+	return bin, nil
+}
+
+// output runs the test binary and returns the lines it prints
+func (t *testBinary) output(args ...string) ([]string, error) {
+	cmd := exec.Command(t.path, args...)
+	cmd.Dir = t.dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", filepath.Base(t.path), strings.Join(args, " "), exitError(err))
+	}
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		// -test.list ends with the ok line of the package
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "ok ") {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// run runs the test binary in a new process, its standard output is written into w
+func (t *testBinary) run(w io.Writer, args ...string) error {
+	cmd := exec.Command(t.path, args...)
+	cmd.Dir = t.dir
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// exitError adds the standard error of the command to the error
+func exitError(err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(exitErr.Stderr))
+	}
+	return err
+}
+
+// selectNames returns the names listed in the comma separated selection, in the order of names, or all the names if
+// the selection is empty
+func selectNames(kind string, names []string, selection string, ignoreCase bool) ([]string, error) {
+	if selection == "" {
+		return names, nil
+	}
+	equal := func(a, b string) bool { return a == b }
+	if ignoreCase {
+		equal = strings.EqualFold
+	}
+	selected := make([]bool, len(names))
+	for _, s := range strings.Split(selection, ",") {
+		s = strings.TrimSpace(s)
+		found := false
+		for i, name := range names {
+			if equal(name, s) {
+				selected[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown %s %q, run with -list to print them", kind, s)
+		}
+	}
+	var result []string
+	for i, name := range names {
+		if selected[i] {
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
+
+// printResults prints the output of a run without the PASS line printed at its end
+func printResults(w io.Writer, output []byte) {
+	s := bufio.NewScanner(bytes.NewReader(output))
+	for s.Scan() {
+		if line := s.Text(); line != "PASS" {
+			fmt.Fprintln(w, line)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package router
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+var compatibilityReportPath = flag.String("compat.report", "", "write the compatibility report of the frameworks with the route sets, in Markdown, into the file")
+
+// probeSegment replaces a static segment of a route path to probe the paths served by a framework, no route declares it
+const probeSegment = "x-probe"
+
+// issueKind classifies how a framework deviates from the meaning of a route table
+type issueKind string
+
+const (
+	// issueRejected is a route that the framework refused to register, with an error or a panic
+	issueRejected issueKind = "rejected"
+	// issueShadowed is a route whose requests are served by another route
+	issueShadowed issueKind = "shadowed"
+	// issueUnreachable is a route whose requests are not served by any route
+	issueUnreachable issueKind = "unreachable"
+	// issueParams is a route that captures other values than the ones of its path variables
+	issueParams issueKind = "params"
+	// issueOverMatch is a route that serves paths it does not declare, for example because the framework reads a
+	// static segment like :name as a path variable
+	issueOverMatch issueKind = "over-match"
+)
+
+var issueKinds = []issueKind{issueRejected, issueShadowed, issueUnreachable, issueParams, issueOverMatch}
+
+// routeIssue is a route that a framework does not serve the way the route table declares it
+type routeIssue struct {
+	Kind   issueKind
+	Route  *Route
+	Detail string
+}
+
+// compatibilityReport describes how a framework serves a route set
+type compatibilityReport struct {
+	Framework string
+	Set       *RouteSet
+	// Registered is the number of routes accepted by the framework
+	Registered int
+	Issues     []routeIssue
+}
+
+// count returns the number of issues of the kind
+func (r *compatibilityReport) count(kind issueKind) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// analyzeCompatibility registers the route set into the framework, leaving out the routes it rejects one at a time,
+// and checks the router with HandlerRouteInfo. Every registered route is requested with its sample URL, which must
+// be served by the route with the expected path variables, and with the paths where one static segment is replaced by
+// probeSegment, which must not be served by a route that doesn't match them.
+// It returns an error only if the router cannot be built for another reason than a rejected route.
+func analyzeCompatibility(framework string, set *RouteSet) (*compatibilityReport, error) {
+	report := &compatibilityReport{Framework: framework, Set: set}
+	routes := append([]*Route(nil), set.Routes...)
+	var newClient func() client
+	for newClient == nil {
+		f, err := safeClientFactory(framework, routes)
+		var regErr *RegistrationError
+		if errors.As(err, &regErr) {
+			report.Issues = append(report.Issues, routeIssue{Kind: issueRejected, Route: regErr.Route, Detail: regErr.Err.Error()})
+			routes = removeRoute(routes, regErr.Route)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		newClient = f
+	}
+	report.Registered = len(routes)
+
+	byKey := make(map[string]*Route, len(routes))
+	for _, r := range routes {
+		byKey[r.Method+" "+r.Path] = r
+	}
+	c := newClient()
+	serve := func(method, path string) (int, *Route, string) {
+		status := c.do(httptest.NewRequest(method, "https://www.domain.com"+path, nil))
+		body := string(c.body())
+		key, _, _ := strings.Cut(body, "\n")
+		return status, byKey[key], body
+	}
+
+	overMatching := map[*Route]bool{}
+	for _, r := range routes {
+		path := strings.TrimPrefix(set.URL(r), "https://www.domain.com")
+		status, served, body := serve(r.Method, path)
+		want := routeInfo(r, func(name string) string {
+			return set.SampleValue(r, name)
+		})
+		switch {
+		case status != 200:
+			report.Issues = append(report.Issues, routeIssue{Kind: issueUnreachable, Route: r, Detail: fmt.Sprintf("%s is answered with %d", path, status)})
+		case served == nil:
+			report.Issues = append(report.Issues, routeIssue{Kind: issueUnreachable, Route: r, Detail: fmt.Sprintf("%s is answered with %q", path, body)})
+		case served != r:
+			report.Issues = append(report.Issues, routeIssue{Kind: issueShadowed, Route: r, Detail: fmt.Sprintf("%s is served by %s", path, served.Path)})
+		case body != want:
+			report.Issues = append(report.Issues, routeIssue{Kind: issueParams, Route: r, Detail: fmt.Sprintf("%s captures %s, want %s", path, describeParams(body), describeParams(want))})
+		}
+
+		segments := strings.Split(path, "/")
+		for i, segment := range strings.Split(r.Path, "/") {
+			if segment == "" || paramName(segment) != "" || i >= len(segments) {
+				continue
+			}
+			probe := strings.Join(append(append(append([]string(nil), segments[:i]...), probeSegment), segments[i+1:]...), "/")
+			if status, served, _ := serve(r.Method, probe); status == 200 && served != nil && !served.Matches(probe) && !overMatching[served] {
+				overMatching[served] = true
+				report.Issues = append(report.Issues, routeIssue{Kind: issueOverMatch, Route: served, Detail: fmt.Sprintf("serves %s", probe)})
+			}
+		}
+	}
+	return report, nil
+}
+
+// safeClientFactory is newClientFactory with HandlerRouteInfo, where a panic raised while building the router is
+// returned as an error
+func safeClientFactory(framework string, routes []*Route) (f func() client, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s panicked while building the router: %v", framework, r)
+		}
+	}()
+	return newClientFactory(framework, routes, HandlerRouteInfo)
+}
+
+func removeRoute(routes []*Route, route *Route) []*Route {
+	for i, r := range routes {
+		if r == route {
+			return append(routes[:i], routes[i+1:]...)
+		}
+	}
+	return routes
+}
+
+// describeParams formats the path variables of a routeInfo body on a single line
+func describeParams(info string) string {
+	_, params, found := strings.Cut(info, "\n")
+	if !found {
+		return "nothing"
+	}
+	return strings.ReplaceAll(params, "\n", ", ")
+}
+
+// writeCompatibilityReport writes the reports in Markdown: for every route set, a table with the number of issues
+// of every framework, followed by the issues of every framework
+func writeCompatibilityReport(w io.Writer, reports []*compatibilityReport) error {
+	var sb strings.Builder
+	sb.WriteString("# Route compatibility\n")
+	for i, r := range reports {
+		if i == 0 || reports[i-1].Set != r.Set {
+			fmt.Fprintf(&sb, "\n## %s (%d routes)\n\n", r.Set.Name, len(r.Set.Routes))
+			sb.WriteString("| Framework | Registered |")
+			for _, kind := range issueKinds {
+				fmt.Fprintf(&sb, " %s |", kind)
+			}
+			sb.WriteString("\n|---|---|")
+			sb.WriteString(strings.Repeat("---|", len(issueKinds)))
+			sb.WriteByte('\n')
+			for _, other := range reports[i:] {
+				if other.Set != r.Set {
+					break
+				}
+				fmt.Fprintf(&sb, "| %s | %d |", other.Framework, other.Registered)
+				for _, kind := range issueKinds {
+					fmt.Fprintf(&sb, " %d |", other.count(kind))
+				}
+				sb.WriteByte('\n')
+			}
+		}
+		if len(r.Issues) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", r.Framework)
+		for _, issue := range r.Issues {
+			fmt.Fprintf(&sb, "- %s `%s %s`: %s\n", issue.Kind, issue.Route.Method, issue.Route.Path, issue.Detail)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// compatibilityRouteSets returns the route sets analysed by TestCompatibility, with the -openapi specification if set
+func compatibilityRouteSets(t *testing.T) ([]*RouteSet, error) {
+	sets := append([]*RouteSet(nil), routeSets...)
+	if *openAPISpec != "" {
+		set, skipped, err := openAPIRouteSet(*openAPISpec)
+		if err != nil {
+			return nil, err
+		}
+		for _, operation := range skipped {
+			t.Logf("%s: operation not analysed, %s", *openAPISpec, operation)
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// TestCompatibility analyses every route set with every framework and logs the issues, or writes the whole report
+// into the -compat.report file. The issues are properties of the frameworks, they don't fail the test.
+func TestCompatibility(t *testing.T) {
+	sets, err := compatibilityRouteSets(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reports []*compatibilityReport
+	for _, set := range sets {
+		for _, name := range benchmarkedFrameworks() {
+			report, err := analyzeCompatibility(name, set)
+			if err != nil {
+				t.Errorf("%s/%s: %v", set.Name, name, err)
+				continue
+			}
+			reports = append(reports, report)
+			for _, issue := range report.Issues {
+				t.Logf("%s/%s: %s %s %s: %s", set.Name, name, issue.Kind, issue.Route.Method, issue.Route.Path, issue.Detail)
+			}
+		}
+	}
+	if *compatibilityReportPath == "" {
+		return
+	}
+	f, err := os.Create(*compatibilityReportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := writeCompatibilityReport(f, reports); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAnalyzeCompatibility(t *testing.T) {
+	set := &RouteSet{Name: "conflicts", Routes: []*Route{
+		{"GET", "/gopher/pencil/gopherhat.jpg"},
+		{"GET", "/gopher/:pencil/gopherhat.jpg"},
+		{"GET", "/users/{id}"},
+	}}
+	tests := []struct {
+		framework string
+		want      map[issueKind]int
+	}{
+		{framework: "gofre", want: map[issueKind]int{}},
+		{framework: "gin", want: map[issueKind]int{issueOverMatch: 1}},
+		{framework: "httprouter", want: map[issueKind]int{issueRejected: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			report, err := analyzeCompatibility(tt.framework, set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Registered != len(set.Routes)-tt.want[issueRejected] {
+				t.Errorf("analyzeCompatibility() got %d registered routes, want: %d", report.Registered, len(set.Routes)-tt.want[issueRejected])
+			}
+			for _, kind := range issueKinds {
+				if got := report.count(kind); got != tt.want[kind] {
+					t.Errorf("analyzeCompatibility() got %d %s issues, want: %d, issues: %+v", got, kind, tt.want[kind], report.Issues)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package router
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// conformanceResult is the outcome of the conformance check of a framework against a route set
+type conformanceResult struct {
+	// err is set when the framework cannot register the route set, so the check was not executed
+	err error
+	// failures lists the requests that were not handled by the expected route or with the expected path variables
+	failures []error
+}
+
+var (
+	conformanceMu      sync.Mutex
+	conformanceResults = map[string]*conformanceResult{}
+)
+
+// checkConformance registers the route set into the framework with HandlerRouteInfo, fires a concrete request
+// for every checked route and verifies that the request was handled by the expected route and that the captured
+// path variables match the URL.
+func checkConformance(framework string, set *RouteSet) *conformanceResult {
+	newClient, err := newClientFactory(framework, set.Routes, HandlerRouteInfo)
+	if err != nil {
+		return &conformanceResult{err: err}
+	}
+
+	checked := set.CheckedRoutes
+	if len(checked) == 0 {
+		checked = set.Routes
+	}
+	c := newClient()
+	result := &conformanceResult{}
+	for i, r := range routeRequests(set, checked) {
+		route := checked[i]
+		want := routeInfo(route, func(name string) string {
+			return set.SampleValue(route, name)
+		})
+		if status := c.do(r); status != 200 {
+			result.failures = append(result.failures, fmt.Errorf("%s %s: got status %d, want 200", r.Method, r.URL.Path, status))
+		} else if got := string(c.body()); got != want {
+			result.failures = append(result.failures, fmt.Errorf("%s %s: got %q, want %q", r.Method, r.URL.Path, got, want))
+		}
+	}
+	return result
+}
+
+// conformance returns the cached result of checkConformance
+func conformance(framework string, set *RouteSet) *conformanceResult {
+	conformanceMu.Lock()
+	defer conformanceMu.Unlock()
+	key := framework + "/" + set.Name
+	result, found := conformanceResults[key]
+	if !found {
+		result = checkConformance(framework, set)
+		conformanceResults[key] = result
+	}
+	return result
+}
+
+// requireConformance skips the benchmark when the framework cannot register the route set and stops it when
+// the framework does not route the set correctly, so no numbers are ever reported for a router that matches
+// the wrong route
+func requireConformance(b *testing.B, framework string, set *RouteSet) {
+	result := conformance(framework, set)
+	if result.err != nil {
+		b.Skipf("%s does not support the %s routes: %v", framework, set.Name, result.err)
+	}
+	if len(result.failures) == 0 {
+		return
+	}
+	for _, f := range result.failures {
+		b.Error(f)
+	}
+	b.Fatalf("%s failed the conformance check for the %s routes", framework, set.Name)
+}
+
+// frameworkRouteSet returns the route set benchmarked for the framework: the set itself or, when the framework cannot
+// register it, its compatible variant
+func frameworkRouteSet(framework string, set *RouteSet) *RouteSet {
+	if set.Compatible != nil && conformance(framework, set).err != nil {
+		return set.Compatible
+	}
+	return set
+}
+
+func TestConformance(t *testing.T) {
+	for _, set := range routeSets {
+		for _, name := range benchmarkedFrameworks() {
+			t.Run(set.Name+"/"+name, func(t *testing.T) {
+				result := conformance(name, set)
+				if result.err != nil {
+					t.Skipf("%s does not support the %s routes: %v", name, set.Name, result.err)
+				}
+				for _, f := range result.failures {
+					t.Error(f)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package router
+
+import (
+	"strings"
+	"testing"
+)
+
+// constraintMissValue is the value given to a constrained path variable to violate its constraint
+const constraintMissValue = "invalid_value"
+
+// constraintMiss gives constraintMissValue to the last constrained path variable of the route, so the router walks
+// the whole path before rejecting it. The routes without constraints, or whose constraint accepts the value, are
+// not missed.
+var constraintMiss = miss{name: "constraint miss", path: func(route *Route, path string) string {
+	constraints := routePattern(route.Path).constraints()
+	if len(constraints) == 0 {
+		return ""
+	}
+	last := constraints[len(constraints)-1]
+	if last.matches(constraintMissValue) {
+		return ""
+	}
+	u := route.URLWith(func(name string) string {
+		if name == last.Value {
+			return constraintMissValue
+		}
+		return route.SampleValue(name)
+	})
+	return strings.TrimPrefix(u, "https://www.domain.com")
+}}
+
+// The Constrained benchmarks compare the frameworks that declare the constraints of the path variables, the regular
+// expressions of GoFre, Gorilla, chi and fasthttp/router and the typed variables of Fiber, with the validation in
+// the handler, what a Gin, Echo, httprouter, pat or http.ServeMux application does.
+
+func Benchmark_Constrained(b *testing.B) {
+	benchmarkRoutes(b, constrainedRouteSet, HandlerOK)
+}
+
+func Benchmark_Constrained_Concurrent(b *testing.B) {
+	benchmarkRoutesConcurrent(b, constrainedRouteSet, HandlerOK)
+}
+
+func Benchmark_Constrained_Invalid(b *testing.B) {
+	benchmarkScenario(b, notFoundScenario(constrainedRouteSet, "constraints", false, constraintMiss))
+}
+
+func Benchmark_Constrained_Invalid_Concurrent(b *testing.B) {
+	benchmarkScenarioConcurrent(b, notFoundScenario(constrainedRouteSet, "constraints", true, constraintMiss))
+}
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"testing"
+)
+
+// The Parse and Google+ route sets are benchmarked like in the classic router benchmark suites: one request to
+// a static route, to a route with one path variable, to a route with two, and requests to all the routes.
+
+func Benchmark_Parse_Static(b *testing.B) {
+	benchmarkScenario(b, pathScenario(parseRouteSet, HandlerOK, "/1/users"))
+}
+
+func Benchmark_Parse_Param(b *testing.B) {
+	benchmarkScenario(b, pathScenario(parseRouteSet, HandlerOK, "/1/classes/GameScore"))
+}
+
+func Benchmark_Parse_2Params(b *testing.B) {
+	benchmarkScenario(b, pathScenario(parseRouteSet, HandlerOK, parseRouteSet.HotPath))
+}
+
+func Benchmark_Parse_Concurrent(b *testing.B) {
+	benchmarkRoutesConcurrent(b, parseRouteSet, HandlerOK)
+}
+
+func Benchmark_GPlus_Static(b *testing.B) {
+	benchmarkScenario(b, pathScenario(gplusRouteSet, HandlerOK, "/people"))
+}
+
+func Benchmark_GPlus_Param(b *testing.B) {
+	benchmarkScenario(b, pathScenario(gplusRouteSet, HandlerOK, "/people/118051310819094153327"))
+}
+
+func Benchmark_GPlus_2Params(b *testing.B) {
+	benchmarkScenario(b, pathScenario(gplusRouteSet, HandlerOK, gplusRouteSet.HotPath))
+}
+
+func Benchmark_GPlus_Concurrent(b *testing.B) {
+	benchmarkRoutesConcurrent(b, gplusRouteSet, HandlerOK)
+}
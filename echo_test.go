@@ -0,0 +1,134 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+func init() {
+	registerFramework("echo", newEchoAdapter)
+}
+
+type echoAdapter struct {
+	e *echo.Echo
+}
+
+func newEchoAdapter() FrameworkAdapter {
+	return &echoAdapter{e: echo.New()}
+}
+
+func (a *echoAdapter) Name() string {
+	return "echo"
+}
+
+func (a *echoAdapter) Version() string {
+	return moduleVersion("github.com/labstack/echo/v4")
+}
+
+// echoSyntax converts the {name} path variables into the :name syntax and the *name catch-all variable into *,
+// because Echo doesn't name it. Echo has no constraints, the handler validates the constrained variables.
+var echoSyntax = pathSyntax{
+	param: colonParam,
+	catchAll: func(string) string {
+		return "*"
+	},
+}
+
+func (a *echoAdapter) TranslatePath(path string) string {
+	return routePattern(path).format(echoSyntax)
+}
+
+func (a *echoAdapter) Use(m Middleware) {
+	a.e.Use(echoMiddleware(m))
+}
+
+func (a *echoAdapter) Register(route *Route, path string, h Handler) (err error) {
+	defer recoverRegistration(&err)
+	handler := echoHandler(route, h)
+	if constraints := routePattern(route.Path).constraints(); constraints != nil {
+		next := handler
+		handler = func(c echo.Context) error {
+			if !constraints.valid(c.Param) {
+				return echo.ErrNotFound
+			}
+			return next(c)
+		}
+	}
+	a.e.Add(route.Method, path, handler)
+	return nil
+}
+
+func (a *echoAdapter) Build() http.Handler {
+	return a.e
+}
+
+func echoHandler(route *Route, h Handler) echo.HandlerFunc {
+	switch h {
+	case HandlerRouteInfo:
+		return func(c echo.Context) error {
+			return c.String(http.StatusOK, routeInfo(route, renameCatchAll(route, "*", c.Param)))
+		}
+	case HandlerParamRead:
+		params := route.NativeParams("*")
+		return func(c echo.Context) error {
+			return c.String(http.StatusOK, joinParams(params, c.Param))
+		}
+	default:
+		return func(c echo.Context) error {
+			return c.String(http.StatusOK, "ok")
+		}
+	}
+}
+
+func echoMiddleware(m Middleware) echo.MiddlewareFunc {
+	switch m {
+	case MiddlewareRequestID:
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				id := requestID(c.Request().Header.Get(requestIDHeader))
+				c.Response().Header().Set(requestIDHeader, id)
+				c.Set(requestIDKey, id)
+				return next(c)
+			}
+		}
+	case MiddlewareRecovery:
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = echo.ErrInternalServerError
+					}
+				}()
+				return next(c)
+			}
+		}
+	case MiddlewareLogging:
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				start := time.Now()
+				// like the Echo logger, the error is handled here so the logged status is the one of the response
+				if err := next(c); err != nil {
+					c.Error(err)
+				}
+				logRequest(c.Request().Method, c.Request().URL.Path, c.Response().Status, start)
+				return nil
+			}
+		}
+	case MiddlewareAuth:
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				if c.Request().Header.Get("Authorization") != authorization {
+					return echo.ErrUnauthorized
+				}
+				return next(c)
+			}
+		}
+	default:
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				return next(c)
+			}
+		}
+	}
+}
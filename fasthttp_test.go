@@ -0,0 +1,239 @@
+package router
+
+import (
+	"fmt"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+)
+
+// fastHTTPPrefix labels the sub-benchmarks of the frameworks built on top of fasthttp.
+// fasthttp replaces the net/http server model (request parsing, pooled request contexts), so their numbers are
+// reported side by side with the net/http frameworks, but they are not measuring the same work.
+const fastHTTPPrefix = "fasthttp/"
+
+// FastHTTPAdapter is the counterpart of FrameworkAdapter for the frameworks that serve a fasthttp.RequestHandler
+// instead of an http.Handler
+type FastHTTPAdapter interface {
+	// Name returns the short name of the framework, used to label the sub-benchmarks
+	Name() string
+	// Version returns the module version of the framework compiled into the benchmark
+	Version() string
+	// TranslatePath converts a path declared with the {name} syntax into the framework path syntax
+	TranslatePath(path string) string
+	// Use adds a middleware to the chain executed for every request, the first added middleware being the outermost.
+	// It is called before the routes are registered.
+	Use(m Middleware)
+	// Register adds a route, whose path is translated by TranslatePath, to the framework router or returns an error if
+	// the framework rejects it
+	Register(route *Route, path string, handler Handler) error
+	// Build returns the fasthttp.RequestHandler that serves all the registered routes
+	Build() fasthttp.RequestHandler
+}
+
+var fastHTTPFrameworks = map[string]func() FastHTTPAdapter{}
+
+// registerFastHTTPFramework makes a fasthttp based framework available to the benchmarks.
+// It must be called from an init function of the adapter file.
+func registerFastHTTPFramework(name string, newAdapter func() FastHTTPAdapter) {
+	if _, found := fastHTTPFrameworks[name]; found {
+		panic(fmt.Sprintf("fasthttp framework %s is already registered", name))
+	}
+	fastHTTPFrameworks[name] = newAdapter
+}
+
+// fastHTTPFrameworkNames returns the names of all registered fasthttp frameworks in alphabetical order
+func fastHTTPFrameworkNames() []string {
+	names := make([]string, 0, len(fastHTTPFrameworks))
+	for name := range fastHTTPFrameworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newFastHTTPRouter creates a new adapter for the named fasthttp framework, adds the middlewares, registers all the
+// routes and builds the router. The returned error is a *RegistrationError when a route path is invalid or when the
+// framework rejects a route.
+func newFastHTTPRouter(name string, routes []*Route, handler Handler, middlewares ...Middleware) (FastHTTPAdapter, fasthttp.RequestHandler, error) {
+	paths, err := translatePaths(routes, fastHTTPFrameworks[name]().TranslatePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return registerFastHTTPRouter(name, routes, paths, handler, middlewares...)
+}
+
+// registerFastHTTPRouter is newFastHTTPRouter with the route paths already translated by translatePaths
+func registerFastHTTPRouter(name string, routes []*Route, paths []string, handler Handler, middlewares ...Middleware) (FastHTTPAdapter, fasthttp.RequestHandler, error) {
+	adapter := fastHTTPFrameworks[name]()
+	for _, m := range middlewares {
+		adapter.Use(m)
+	}
+	for i, r := range routes {
+		if err := adapter.Register(r, paths[i], handler); err != nil {
+			return nil, nil, &RegistrationError{Route: r, Err: err}
+		}
+	}
+	return adapter, adapter.Build(), nil
+}
+
+// fastHTTPClient serves the requests with a single fasthttp.RequestCtx, the same way a fasthttp server reuses
+// the context of a connection
+type fastHTTPClient struct {
+	handler fasthttp.RequestHandler
+	ctx     fasthttp.RequestCtx
+	// last is the request copied into the context. A request served several times in a row is copied once, like the
+	// requests of the net/http frameworks that are built once, so the copy of its headers is not measured.
+	last *http.Request
+	// headers reports whether the last request had headers, which must be removed before the next request
+	headers bool
+}
+
+func (c *fastHTTPClient) do(r *http.Request) int {
+	if r != c.last {
+		c.copyRequest(r)
+	}
+	c.ctx.Response.Reset()
+	// like a fasthttp server between two requests, so the path variables of a request don't leak into the next one
+	c.ctx.ResetUserValues()
+	c.handler(&c.ctx)
+	return c.ctx.Response.StatusCode()
+}
+
+// copyRequest copies the method, the URI and the headers of the request into the context
+func (c *fastHTTPClient) copyRequest(r *http.Request) {
+	// the request headers are reset only when needed, so the requests without headers don't pay for it
+	if c.headers {
+		c.ctx.Request.Header.Reset()
+	}
+	c.headers = len(r.Header) > 0
+	for name, values := range r.Header {
+		for _, v := range values {
+			c.ctx.Request.Header.Add(name, v)
+		}
+	}
+	c.ctx.Request.Header.SetMethod(r.Method)
+	c.ctx.Request.SetRequestURI(r.RequestURI)
+	c.last = r
+}
+
+func (c *fastHTTPClient) body() []byte {
+	return c.ctx.Response.Body()
+}
+
+func (c *fastHTTPClient) header(name string) string {
+	return string(c.ctx.Response.Header.Peek(name))
+}
+
+// fastHTTPMiddleware returns the middleware as a func(fasthttp.RequestHandler) fasthttp.RequestHandler, the counterpart
+// of httpMiddleware for the fasthttp frameworks without middlewares
+func fastHTTPMiddleware(m Middleware) func(fasthttp.RequestHandler) fasthttp.RequestHandler {
+	switch m {
+	case MiddlewareRequestID:
+		return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+			return func(ctx *fasthttp.RequestCtx) {
+				id := requestID(string(ctx.Request.Header.Peek(requestIDHeader)))
+				ctx.Response.Header.Set(requestIDHeader, id)
+				ctx.SetUserValue(requestIDKey, id)
+				next(ctx)
+			}
+		}
+	case MiddlewareRecovery:
+		return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+			return func(ctx *fasthttp.RequestCtx) {
+				defer func() {
+					if err := recover(); err != nil {
+						ctx.Error(http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					}
+				}()
+				next(ctx)
+			}
+		}
+	case MiddlewareLogging:
+		return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+			return func(ctx *fasthttp.RequestCtx) {
+				start := time.Now()
+				next(ctx)
+				logRequest(string(ctx.Method()), string(ctx.Path()), ctx.Response.StatusCode(), start)
+			}
+		}
+	case MiddlewareAuth:
+		return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+			return func(ctx *fasthttp.RequestCtx) {
+				if string(ctx.Request.Header.Peek("Authorization")) != authorization {
+					ctx.Error(http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+					return
+				}
+				next(ctx)
+			}
+		}
+	default:
+		return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+			return func(ctx *fasthttp.RequestCtx) {
+				next(ctx)
+			}
+		}
+	}
+}
+
+// wrapFastHTTPMiddlewares wraps the handler into the middlewares, the first one being the outermost
+func wrapFastHTTPMiddlewares(h fasthttp.RequestHandler, middlewares []Middleware) fasthttp.RequestHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = fastHTTPMiddleware(middlewares[i])(h)
+	}
+	return h
+}
+
+// writeFastHTTPPlainText writes a 200 plain text response for the frameworks that hand the fasthttp.RequestCtx to the handler
+func writeFastHTTPPlainText(ctx *fasthttp.RequestCtx, body string) {
+	ctx.SetContentType("text/plain; charset=utf-8")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.WriteString(body)
+}
+
+func TestFastHTTPClient_resetUserValues(t *testing.T) {
+	routes := []*Route{{"GET", "/users/{id}"}, {"GET", "/status"}}
+	for _, name := range fastHTTPFrameworkNames() {
+		t.Run(name, func(t *testing.T) {
+			newClient, err := newClientFactory(fastHTTPPrefix+name, routes, HandlerOK)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := newClient().(*fastHTTPClient)
+			c.do(httptest.NewRequest(http.MethodGet, "https://www.domain.com/users/42", nil))
+			c.do(httptest.NewRequest(http.MethodGet, "https://www.domain.com/status", nil))
+			c.ctx.VisitUserValues(func(key []byte, v any) {
+				t.Errorf("the user value %s=%v of the previous request is kept", key, v)
+			})
+		})
+	}
+}
+
+func TestFastHTTPClient_repeatedRequest(t *testing.T) {
+	for _, name := range fastHTTPFrameworkNames() {
+		t.Run(name, func(t *testing.T) {
+			newClient, err := newClientFactory(fastHTTPPrefix+name, parseRouteSet.Routes, HandlerOK, MiddlewareRequestID, MiddlewareAuth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := newClient()
+			r := httptest.NewRequest(http.MethodGet, "https://www.domain.com"+parseRouteSet.HotPath, nil)
+			r.Header.Set("Authorization", authorization)
+			r.Header.Set(requestIDHeader, "42")
+			for i := 0; i < 3; i++ {
+				if status := c.do(r); status != http.StatusOK {
+					t.Fatalf("got %d for the request %d, want: %d", status, i+1, http.StatusOK)
+				}
+				if id := c.header(requestIDHeader); id != "42" {
+					t.Fatalf("got the request ID %q for the request %d, want: 42", id, i+1)
+				}
+			}
+			if status := c.do(httptest.NewRequest(http.MethodGet, "https://www.domain.com"+parseRouteSet.HotPath, nil)); status != http.StatusUnauthorized {
+				t.Errorf("got %d for a request without the token, want: %d", status, http.StatusUnauthorized)
+			}
+		})
+	}
+}
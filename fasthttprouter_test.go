@@ -0,0 +1,88 @@
+package router
+
+import (
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+)
+
+func init() {
+	registerFastHTTPFramework("fasthttprouter", newFastHTTPRouterAdapter)
+}
+
+type fastHTTPRouterAdapter struct {
+	r           *router.Router
+	middlewares []Middleware
+}
+
+func newFastHTTPRouterAdapter() FastHTTPAdapter {
+	r := router.New()
+	// a miss must be rejected, not redirected, like in the frameworks that don't fix the path
+	r.RedirectTrailingSlash = false
+	r.RedirectFixedPath = false
+	return &fastHTTPRouterAdapter{r: r}
+}
+
+func (a *fastHTTPRouterAdapter) Name() string {
+	return "fasthttprouter"
+}
+
+func (a *fastHTTPRouterAdapter) Version() string {
+	return moduleVersion("github.com/fasthttp/router")
+}
+
+// fastHTTPRouterSyntax keeps the {name} and {name:regexp} syntax and converts the *name catch-all variable into
+// {name:*}
+var fastHTTPRouterSyntax = pathSyntax{
+	param: bracesParam,
+	constrained: func(s *patternSegment) string {
+		return "{" + s.Value + ":" + s.Pattern + "}"
+	},
+	catchAll: func(name string) string {
+		return "{" + name + ":*}"
+	},
+}
+
+func (a *fastHTTPRouterAdapter) TranslatePath(path string) string {
+	return routePattern(path).format(fastHTTPRouterSyntax)
+}
+
+// Use adds a middleware wrapping the router, fasthttp/router has no middlewares
+func (a *fastHTTPRouterAdapter) Use(m Middleware) {
+	a.middlewares = append(a.middlewares, m)
+}
+
+func (a *fastHTTPRouterAdapter) Register(route *Route, path string, h Handler) (err error) {
+	defer recoverRegistration(&err)
+	a.r.Handle(route.Method, path, fastHTTPRouterHandler(route, h))
+	return nil
+}
+
+func (a *fastHTTPRouterAdapter) Build() fasthttp.RequestHandler {
+	return wrapFastHTTPMiddlewares(a.r.Handler, a.middlewares)
+}
+
+func fastHTTPRouterHandler(route *Route, h Handler) fasthttp.RequestHandler {
+	switch h {
+	case HandlerRouteInfo:
+		return func(ctx *fasthttp.RequestCtx) {
+			writeFastHTTPPlainText(ctx, routeInfo(route, fastHTTPRouterParam(ctx)))
+		}
+	case HandlerParamRead:
+		params := route.Params()
+		return func(ctx *fasthttp.RequestCtx) {
+			writeFastHTTPPlainText(ctx, joinParams(params, fastHTTPRouterParam(ctx)))
+		}
+	default:
+		return func(ctx *fasthttp.RequestCtx) {
+			writeFastHTTPPlainText(ctx, "ok")
+		}
+	}
+}
+
+// fastHTTPRouterParam returns the accessor of the path variables, stored by the router as user values
+func fastHTTPRouterParam(ctx *fasthttp.RequestCtx) func(name string) string {
+	return func(name string) string {
+		v, _ := ctx.UserValue(name).(string)
+		return v
+	}
+}
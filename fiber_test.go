@@ -0,0 +1,142 @@
+package router
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"time"
+)
+
+func init() {
+	registerFastHTTPFramework("fiber", newFiberAdapter)
+}
+
+type fiberAdapter struct {
+	app *fiber.App
+}
+
+func newFiberAdapter() FastHTTPAdapter {
+	return &fiberAdapter{app: fiber.New(fiber.Config{
+		CaseSensitive: true,
+		StrictRouting: true,
+	})}
+}
+
+func (a *fiberAdapter) Name() string {
+	return "fiber"
+}
+
+func (a *fiberAdapter) Version() string {
+	return moduleVersion("github.com/gofiber/fiber/v2")
+}
+
+// fiberConstraints are the Fiber constraints of the typed path variables
+var fiberConstraints = map[string]string{"int": "int", "alpha": "alpha", "uuid": "guid"}
+
+// fiberSyntax converts the {name} path variables into the :name syntax and the *name catch-all variable into *,
+// because Fiber doesn't name it. The typed variables use the Fiber constraints, like :id<int>, the other ones
+// the regex constraint, which is not anchored.
+var fiberSyntax = pathSyntax{
+	param: colonParam,
+	constrained: func(s *patternSegment) string {
+		if c, found := fiberConstraints[s.Type]; found {
+			return ":" + s.Value + "<" + c + ">"
+		}
+		return ":" + s.Value + "<regex(" + s.anchoredPattern() + ")>"
+	},
+	catchAll: func(string) string {
+		return "*"
+	},
+}
+
+func (a *fiberAdapter) TranslatePath(path string) string {
+	return routePattern(path).format(fiberSyntax)
+}
+
+func (a *fiberAdapter) Use(m Middleware) {
+	a.app.Use(fiberMiddleware(m))
+}
+
+// Register uses App.Get for the GET routes, like a Fiber application does, which also serves the HEAD requests
+func (a *fiberAdapter) Register(route *Route, path string, h Handler) (err error) {
+	defer recoverRegistration(&err)
+	if route.Method == http.MethodGet {
+		a.app.Get(path, fiberHandler(route, h))
+	} else {
+		a.app.Add(route.Method, path, fiberHandler(route, h))
+	}
+	return nil
+}
+
+func (a *fiberAdapter) Build() fasthttp.RequestHandler {
+	return a.app.Handler()
+}
+
+func fiberHandler(route *Route, h Handler) fiber.Handler {
+	switch h {
+	case HandlerRouteInfo:
+		return func(c *fiber.Ctx) error {
+			return c.Status(http.StatusOK).SendString(routeInfo(route, renameCatchAll(route, "*", fiberParam(c))))
+		}
+	case HandlerParamRead:
+		params := route.NativeParams("*")
+		return func(c *fiber.Ctx) error {
+			return c.Status(http.StatusOK).SendString(joinParams(params, fiberParam(c)))
+		}
+	default:
+		return func(c *fiber.Ctx) error {
+			return c.Status(http.StatusOK).SendString("ok")
+		}
+	}
+}
+
+// fiberParam adapts the variadic fiber.Ctx.Params to the accessor expected by routeInfo and joinParams
+func fiberParam(c *fiber.Ctx) func(name string) string {
+	return func(name string) string {
+		return c.Params(name)
+	}
+}
+
+func fiberMiddleware(m Middleware) fiber.Handler {
+	switch m {
+	case MiddlewareRequestID:
+		return func(c *fiber.Ctx) error {
+			id := requestID(c.Get(requestIDHeader))
+			c.Set(requestIDHeader, id)
+			c.Locals(requestIDKey, id)
+			return c.Next()
+		}
+	case MiddlewareRecovery:
+		return func(c *fiber.Ctx) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fiber.ErrInternalServerError
+				}
+			}()
+			return c.Next()
+		}
+	case MiddlewareLogging:
+		return func(c *fiber.Ctx) error {
+			start := time.Now()
+			// like the Fiber logger, the error is handled here so the logged status is the one of the response
+			if err := c.Next(); err != nil {
+				if err := c.App().ErrorHandler(c, err); err != nil {
+					c.SendStatus(http.StatusInternalServerError)
+				}
+			}
+			logRequest(c.Method(), c.Path(), c.Response().StatusCode(), start)
+			return nil
+		}
+	case MiddlewareAuth:
+		return func(c *fiber.Ctx) error {
+			if c.Get("Authorization") != authorization {
+				return fiber.ErrUnauthorized
+			}
+			return c.Next()
+		}
+	default:
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+}
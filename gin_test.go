@@ -0,0 +1,117 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+func init() {
+	registerFramework("gin", newGinAdapter)
+}
+
+type ginAdapter struct {
+	g *gin.Engine
+}
+
+func newGinAdapter() FrameworkAdapter {
+	gin.SetMode(gin.ReleaseMode)
+	g := gin.New()
+	// a miss must be rejected, not redirected, like in the frameworks that don't fix the path
+	g.RedirectTrailingSlash = false
+	return &ginAdapter{g: g}
+}
+
+func (a *ginAdapter) Name() string {
+	return "gin"
+}
+
+func (a *ginAdapter) Version() string {
+	return moduleVersion("github.com/gin-gonic/gin")
+}
+
+func (a *ginAdapter) TranslatePath(path string) string {
+	return routePattern(path).format(colonSyntax)
+}
+
+func (a *ginAdapter) Use(m Middleware) {
+	a.g.Use(ginMiddleware(m))
+}
+
+func (a *ginAdapter) Register(route *Route, path string, h Handler) (err error) {
+	defer recoverRegistration(&err)
+	handler := ginHandler(route, h)
+	if constraints := routePattern(route.Path).constraints(); constraints != nil {
+		next := handler
+		handler = func(c *gin.Context) {
+			if !constraints.valid(c.Param) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			next(c)
+		}
+	}
+	a.g.Handle(route.Method, path, handler)
+	return nil
+}
+
+func (a *ginAdapter) Build() http.Handler {
+	return a.g
+}
+
+func ginHandler(route *Route, h Handler) gin.HandlerFunc {
+	switch h {
+	case HandlerRouteInfo:
+		return func(c *gin.Context) {
+			c.String(http.StatusOK, routeInfo(route, c.Param))
+		}
+	case HandlerParamRead:
+		params := route.Params()
+		return func(c *gin.Context) {
+			c.String(http.StatusOK, joinParams(params, c.Param))
+		}
+	default:
+		return func(c *gin.Context) {
+			c.String(http.StatusOK, "ok")
+		}
+	}
+}
+
+func ginMiddleware(m Middleware) gin.HandlerFunc {
+	switch m {
+	case MiddlewareRequestID:
+		return func(c *gin.Context) {
+			id := requestID(c.GetHeader(requestIDHeader))
+			c.Header(requestIDHeader, id)
+			c.Set(requestIDKey, id)
+			c.Next()
+		}
+	case MiddlewareRecovery:
+		return func(c *gin.Context) {
+			defer func() {
+				if err := recover(); err != nil {
+					c.AbortWithStatus(http.StatusInternalServerError)
+				}
+			}()
+			c.Next()
+		}
+	case MiddlewareLogging:
+		return func(c *gin.Context) {
+			start := time.Now()
+			c.Next()
+			logRequest(c.Request.Method, c.Request.URL.Path, c.Writer.Status(), start)
+		}
+	case MiddlewareAuth:
+		return func(c *gin.Context) {
+			if c.GetHeader("Authorization") != authorization {
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+			c.Next()
+		}
+	default:
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+func init() {
+	registerFramework("gorilla", newGorillaAdapter)
+}
+
+type gorillaAdapter struct {
+	r *mux.Router
+}
+
+func newGorillaAdapter() FrameworkAdapter {
+	return &gorillaAdapter{r: mux.NewRouter()}
+}
+
+func (a *gorillaAdapter) Name() string {
+	return "gorilla"
+}
+
+func (a *gorillaAdapter) Version() string {
+	return moduleVersion("github.com/gorilla/mux")
+}
+
+// gorillaSyntax keeps the {name} and {name:regexp} syntax and converts the *name catch-all variable into
+// a {name:.*} regular expression
+var gorillaSyntax = pathSyntax{
+	param: bracesParam,
+	constrained: func(s *patternSegment) string {
+		return "{" + s.Value + ":" + s.Pattern + "}"
+	},
+	catchAll: func(name string) string {
+		return "{" + name + ":.*}"
+	},
+}
+
+func (a *gorillaAdapter) TranslatePath(path string) string {
+	return routePattern(path).format(gorillaSyntax)
+}
+
+// Use registers the middleware with Router.Use, Gorilla executes it only for the requests matching a route
+func (a *gorillaAdapter) Use(m Middleware) {
+	a.r.Use(httpMiddleware(m))
+}
+
+func (a *gorillaAdapter) Register(route *Route, path string, h Handler) (err error) {
+	defer recoverRegistration(&err)
+	return a.r.HandleFunc(path, gorillaHandler(route, h)).Methods(route.Method).GetError()
+}
+
+func (a *gorillaAdapter) Build() http.Handler {
+	return a.r
+}
+
+func gorillaHandler(route *Route, h Handler) http.HandlerFunc {
+	switch h {
+	case HandlerRouteInfo:
+		return func(w http.ResponseWriter, r *http.Request) {
+			vars := mux.Vars(r)
+			writePlainText(w, routeInfo(route, func(name string) string {
+				return vars[name]
+			}))
+		}
+	case HandlerParamRead:
+		params := route.Params()
+		return func(w http.ResponseWriter, r *http.Request) {
+			vars := mux.Vars(r)
+			writePlainText(w, joinParams(params, func(name string) string {
+				return vars[name]
+			}))
+		}
+	default:
+		return func(w http.ResponseWriter, r *http.Request) {
+			writePlainText(w, "ok")
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package router
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+func init() {
+	registerFramework("httprouter", newHttpRouterAdapter)
+}
+
+type httpRouterAdapter struct {
+	r           *httprouter.Router
+	middlewares []Middleware
+}
+
+func newHttpRouterAdapter() FrameworkAdapter {
+	r := httprouter.New()
+	// a miss must be rejected, not redirected, like in the frameworks that don't fix the path
+	r.RedirectTrailingSlash = false
+	r.RedirectFixedPath = false
+	return &httpRouterAdapter{r: r}
+}
+
+func (a *httpRouterAdapter) Name() string {
+	return "httprouter"
+}
+
+func (a *httpRouterAdapter) Version() string {
+	return moduleVersion("github.com/julienschmidt/httprouter")
+}
+
+func (a *httpRouterAdapter) TranslatePath(path string) string {
+	return routePattern(path).format(colonSyntax)
+}
+
+// Use adds a middleware wrapping the router, httprouter has no middlewares
+func (a *httpRouterAdapter) Use(m Middleware) {
+	a.middlewares = append(a.middlewares, m)
+}
+
+func (a *httpRouterAdapter) Register(route *Route, path string, h Handler) (err error) {
+	defer recoverRegistration(&err)
+	handler := httpRouterHandler(route, h)
+	if constraints := routePattern(route.Path).constraints(); constraints != nil {
+		next := handler
+		handler = func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			if !constraints.valid(ps.ByName) {
+				http.NotFound(w, r)
+				return
+			}
+			next(w, r, ps)
+		}
+	}
+	a.r.Handle(route.Method, path, handler)
+	return nil
+}
+
+func (a *httpRouterAdapter) Build() http.Handler {
+	return wrapHTTPMiddlewares(a.r, a.middlewares)
+}
+
+func httpRouterHandler(route *Route, h Handler) httprouter.Handle {
+	switch h {
+	case HandlerRouteInfo:
+		return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			writePlainText(w, routeInfo(route, ps.ByName))
+		}
+	case HandlerParamRead:
+		params := route.Params()
+		return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			writePlainText(w, joinParams(params, ps.ByName))
+		}
+	default:
+		return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			writePlainText(w, "ok")
+		}
+	}
+}
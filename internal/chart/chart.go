@@ -0,0 +1,143 @@
+// Package chart renders the grouped bar charts published in the README.
+//
+// The charts use a logarithmic Y axis because the compared values (total requests, ns/op, B/op and allocs/op)
+// differ by several orders of magnitude.
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Series is a named row of values, one per group
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// BarChart is a grouped bar chart: for every group one bar is drawn per series
+type BarChart struct {
+	Title  string
+	XLabel string
+	Groups []string
+	Series []Series
+}
+
+// palette is the color used by the series, in order
+var palette = []string{
+	"#3366cc", "#dc3912", "#ff9900", "#109618", "#990099", "#0099c6", "#dd4477", "#66aa00", "#b82e2e", "#316395",
+}
+
+const (
+	width        = 1360
+	height       = 780
+	plotLeft     = 230
+	plotTop      = 150
+	plotRight    = 1000
+	plotBottom   = 630
+	legendLeft   = 1025
+	legendTop    = 145
+	legendRowGap = 42
+	groupPadding = 0.2
+)
+
+// layout contains the computed geometry shared by the SVG and PNG renderers
+type layout struct {
+	maxExp int
+	bars   []bar
+	ticks  []tick
+}
+
+type bar struct {
+	series         int
+	x0, y0, x1, y1 float64
+}
+
+type tick struct {
+	y     float64
+	label string
+	major bool
+}
+
+func (c *BarChart) validate() error {
+	if len(c.Groups) == 0 || len(c.Series) == 0 {
+		return fmt.Errorf("chart %q has no data", c.Title)
+	}
+	for _, s := range c.Series {
+		if len(s.Values) != len(c.Groups) {
+			return fmt.Errorf("series %q has %d values, expected %d", s.Name, len(s.Values), len(c.Groups))
+		}
+	}
+	return nil
+}
+
+func (c *BarChart) layout() layout {
+	var maxValue float64
+	for _, s := range c.Series {
+		for _, v := range s.Values {
+			maxValue = math.Max(maxValue, v)
+		}
+	}
+	maxExp := int(math.Ceil(math.Log10(math.Max(maxValue, 10))))
+
+	l := layout{maxExp: maxExp}
+	for e := 0; e <= maxExp; e++ {
+		major := e%2 == 0 || maxExp < 5
+		l.ticks = append(l.ticks, tick{y: scaleY(maxExp, math.Pow(10, float64(e))), label: humanize(math.Pow(10, float64(e))), major: major})
+		if e < maxExp {
+			for m := 2; m < 10; m++ {
+				l.ticks = append(l.ticks, tick{y: scaleY(maxExp, float64(m)*math.Pow(10, float64(e)))})
+			}
+		}
+	}
+	l.ticks = append(l.ticks, tick{y: plotBottom, label: "0", major: true})
+
+	groupWidth := float64(plotRight-plotLeft) / float64(len(c.Groups))
+	barWidth := groupWidth * (1 - 2*groupPadding) / float64(len(c.Series))
+	for g := range c.Groups {
+		x := plotLeft + float64(g)*groupWidth + groupWidth*groupPadding
+		for s, series := range c.Series {
+			x0 := x + float64(s)*barWidth
+			l.bars = append(l.bars, bar{
+				series: s,
+				x0:     x0 + 1,
+				x1:     x0 + barWidth - 1,
+				y0:     scaleY(maxExp, series.Values[g]),
+				y1:     plotBottom,
+			})
+		}
+	}
+	return l
+}
+
+// scaleY maps a value on the logarithmic axis. The values between 0 and 1 are drawn linearly below the first decade.
+func scaleY(maxExp int, v float64) float64 {
+	const zeroBand = 0.5
+	span := float64(maxExp) + zeroBand
+	var pos float64
+	if v <= 1 {
+		pos = math.Max(v, 0) * zeroBand
+	} else {
+		pos = zeroBand + math.Log10(v)
+	}
+	return plotBottom - pos/span*float64(plotBottom-plotTop)
+}
+
+// humanize formats a power of ten the same way the original spreadsheet charts did
+func humanize(v float64) string {
+	switch {
+	case v >= 1e9:
+		return fmt.Sprintf("%s billion", trimZeros(v/1e9))
+	case v >= 1e6:
+		return fmt.Sprintf("%s million", trimZeros(v/1e6))
+	case v >= 1e3:
+		return fmt.Sprintf("%s thousand", trimZeros(v/1e3))
+	default:
+		return trimZeros(v)
+	}
+}
+
+func trimZeros(v float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
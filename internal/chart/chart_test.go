@@ -0,0 +1,97 @@
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func newTestChart() *BarChart {
+	return &BarChart{
+		Title:  "Performance - Static Resources (single-thread)",
+		XLabel: "Performance Parameters",
+		Groups: []string{"Total\nRequests", "ns/op", "B/op", "allocs/op"},
+		Series: []Series{
+			{Name: "gofre", Values: []float64{2500000, 480.5, 70, 1}},
+			{Name: "fasthttp/fiber", Values: []float64{1000000, 1100, 0, 0}},
+		},
+	}
+}
+
+func TestBarChart_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		chart   *BarChart
+		wantErr bool
+	}{
+		{name: "valid", chart: newTestChart()},
+		{name: "no groups", chart: &BarChart{Series: []Series{{Name: "gofre"}}}, wantErr: true},
+		{name: "no series", chart: &BarChart{Groups: []string{"ns/op"}}, wantErr: true},
+		{name: "missing values", chart: &BarChart{Groups: []string{"ns/op", "B/op"}, Series: []Series{{Name: "gofre", Values: []float64{1}}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.chart.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error: %v, wantErr: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBarChart_PNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newTestChart().PNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != width || b.Dy() != height {
+		t.Errorf("PNG() got an image of %dx%d, want: %dx%d", b.Dx(), b.Dy(), width, height)
+	}
+}
+
+func TestBarChart_SVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newTestChart().SVG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	for _, want := range []string{"<svg ", "</svg>", "Static Resources (single-thread)", "fasthttp/fiber", "Requests", "1 million"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG() does not contain %q", want)
+		}
+	}
+	if got, want := strings.Count(svg, `fill="`+palette[0]+`"`), 4+1; got != want {
+		t.Errorf("SVG() got %d elements of the first series, want: %d", got, want)
+	}
+}
+
+func TestScaleY(t *testing.T) {
+	maxExp := 6
+	if y := scaleY(maxExp, 0); y != plotBottom {
+		t.Errorf("scaleY(0) got: %v, want: %v", y, plotBottom)
+	}
+	if y := scaleY(maxExp, 1e6); y != plotTop {
+		t.Errorf("scaleY(1e6) got: %v, want: %v", y, plotTop)
+	}
+	if y1, y2 := scaleY(maxExp, 10), scaleY(maxExp, 100); y1 <= y2 {
+		t.Errorf("scaleY(10) = %v must be below scaleY(100) = %v", y1, y2)
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	tests := map[float64]string{
+		1:     "1",
+		1000:  "1 thousand",
+		1e6:   "1 million",
+		2.5e9: "2.5 billion",
+	}
+	for v, want := range tests {
+		if got := humanize(v); got != want {
+			t.Errorf("humanize(%v) got: %s, want: %s", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+var (
+	regularFace = mustFace(goregular.TTF, 22)
+	boldFace    = mustFace(gobold.TTF, 22)
+	italicFace  = mustFace(goitalic.TTF, 22)
+
+	gridMajor = color.RGBA{R: 0xcc, G: 0xcc, B: 0xcc, A: 0xff}
+	gridMinor = color.RGBA{R: 0xeb, G: 0xeb, B: 0xeb, A: 0xff}
+	textColor = color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff}
+)
+
+func mustFace(ttf []byte, size float64) font.Face {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(err)
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		panic(err)
+	}
+	return face
+}
+
+// PNG renders the chart as a PNG image
+func (c *BarChart) PNG(w io.Writer) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	l := c.layout()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for _, t := range l.ticks {
+		col := gridMinor
+		if t.major {
+			col = gridMajor
+		}
+		fillRect(img, plotLeft, int(t.y), plotRight, int(t.y)+1, col)
+		if t.label != "" {
+			drawText(img, regularFace, t.label, plotLeft-12, int(t.y)+8, alignRight)
+		}
+	}
+	for _, b := range l.bars {
+		fillRect(img, int(b.x0), int(b.y0), int(b.x1), int(b.y1), parseHex(palette[b.series%len(palette)]))
+	}
+	fillRect(img, plotLeft, plotBottom, plotRight, plotBottom+2, textColor)
+
+	groupWidth := float64(plotRight-plotLeft) / float64(len(c.Groups))
+	for g, name := range c.Groups {
+		x := plotLeft + int(groupWidth*(float64(g)+0.5))
+		for i, line := range strings.Split(name, "\n") {
+			drawText(img, regularFace, line, x, plotBottom+40+i*32, alignCenter)
+		}
+	}
+	for s, series := range c.Series {
+		y := legendTop + s*legendRowGap
+		fillRect(img, legendLeft, y, legendLeft+50, y+26, parseHex(palette[s%len(palette)]))
+		drawText(img, regularFace, series.Name, legendLeft+62, y+21, alignLeft)
+	}
+	drawText(img, boldFace, c.Title, plotLeft, plotTop-50, alignLeft)
+	if c.XLabel != "" {
+		drawText(img, italicFace, c.XLabel, (plotLeft+plotRight)/2, height-25, alignCenter)
+	}
+	return png.Encode(w, img)
+}
+
+const (
+	alignLeft = iota
+	alignCenter
+	alignRight
+)
+
+func drawText(img draw.Image, face font.Face, text string, x, y int, align int) {
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(textColor), Face: face}
+	textWidth := d.MeasureString(text).Round()
+	switch align {
+	case alignCenter:
+		x -= textWidth / 2
+	case alignRight:
+		x -= textWidth
+	}
+	d.Dot = fixed.P(x, y)
+	d.DrawString(text)
+}
+
+func fillRect(img draw.Image, x0, y0, x1, y1 int, c color.Color) {
+	draw.Draw(img, image.Rect(x0, y0, x1, y1), image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func parseHex(hex string) color.RGBA {
+	v, _ := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
@@ -0,0 +1,54 @@
+package chart
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// SVG renders the chart as an SVG document, with the same geometry as the PNG image
+func (c *BarChart) SVG(w io.Writer) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	l := c.layout()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Go, Arial, sans-serif" font-size="22">`+"\n", width, height, width, height)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+
+	for _, t := range l.ticks {
+		col := gridMinor
+		if t.major {
+			col = gridMajor
+		}
+		fmt.Fprintf(bw, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#%02x%02x%02x"/>`+"\n", plotLeft, t.y, plotRight, t.y, col.R, col.G, col.B)
+		if t.label != "" {
+			fmt.Fprintf(bw, `<text x="%d" y="%.1f" text-anchor="end">%s</text>`+"\n", plotLeft-12, t.y+8, html.EscapeString(t.label))
+		}
+	}
+	for _, b := range l.bars {
+		fmt.Fprintf(bw, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n", b.x0, b.y0, b.x1-b.x0, b.y1-b.y0, palette[b.series%len(palette)])
+	}
+	fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="2" fill="#222222"/>`+"\n", plotLeft, plotBottom, plotRight-plotLeft)
+
+	groupWidth := float64(plotRight-plotLeft) / float64(len(c.Groups))
+	for g, name := range c.Groups {
+		x := plotLeft + groupWidth*(float64(g)+0.5)
+		for i, line := range strings.Split(name, "\n") {
+			fmt.Fprintf(bw, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n", x, plotBottom+40+i*32, html.EscapeString(line))
+		}
+	}
+	for s, series := range c.Series {
+		y := legendTop + s*legendRowGap
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="50" height="26" fill="%s"/>`+"\n", legendLeft, y, palette[s%len(palette)])
+		fmt.Fprintf(bw, `<text x="%d" y="%d">%s</text>`+"\n", legendLeft+62, y+21, html.EscapeString(series.Name))
+	}
+	fmt.Fprintf(bw, `<text x="%d" y="%d" font-weight="bold">%s</text>`+"\n", plotLeft, plotTop-50, html.EscapeString(c.Title))
+	if c.XLabel != "" {
+		fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="middle" font-style="italic">%s</text>`+"\n", (plotLeft+plotRight)/2, height-25, html.EscapeString(c.XLabel))
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
@@ -0,0 +1,149 @@
+// Package hdr implements a latency histogram in the style of HdrHistogram: the values are counted in log-linear buckets,
+// so recording is constant time and allocation free, and every percentile is reported with a relative error lower
+// than 1%, whatever the magnitude of the values.
+package hdr
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	// subBucketBits sets the precision: every power of two range is split into 2^subBucketBits linear buckets
+	subBucketBits  = 7
+	subBucketCount = 1 << subBucketBits
+	// HighestTrackableValue is the largest value counted in its own bucket, larger values are counted as this value.
+	// In nanoseconds, it is more than 18 minutes.
+	HighestTrackableValue = 1<<40 - 1
+)
+
+// bucketsLen is the number of buckets needed to count the values up to HighestTrackableValue
+var bucketsLen = bucketIndex(HighestTrackableValue) + 1
+
+// Histogram counts int64 values, usually latencies in nanoseconds. It is not safe for concurrent use:
+// every goroutine records into its own histogram and the histograms are merged at the end.
+type Histogram struct {
+	counts []int64
+	total  int64
+	sum    float64
+	min    int64
+	max    int64
+}
+
+// New returns an empty histogram
+func New() *Histogram {
+	return &Histogram{counts: make([]int64, bucketsLen), min: math.MaxInt64}
+}
+
+// bucketIndex returns the index of the bucket counting the value. The values lower than subBucketCount have their own
+// bucket, above them every power of two range is split into subBucketCount buckets of the same width.
+func bucketIndex(v int64) int {
+	if v < subBucketCount {
+		return int(v)
+	}
+	exp := bits.Len64(uint64(v)) - subBucketBits - 1
+	return exp*subBucketCount + int(v>>exp)
+}
+
+// bucketHighestValue returns the largest value counted by the bucket
+func bucketHighestValue(index int) int64 {
+	if index < subBucketCount {
+		return int64(index)
+	}
+	exp := index/subBucketCount - 1
+	mantissa := int64(index%subBucketCount + subBucketCount)
+	return (mantissa+1)<<exp - 1
+}
+
+// Record counts a value. The negative values are counted as 0.
+func (h *Histogram) Record(v int64) {
+	if v < 0 {
+		v = 0
+	}
+	if v < h.min {
+		h.min = v
+	}
+	if v > h.max {
+		h.max = v
+	}
+	h.total++
+	h.sum += float64(v)
+	if v > HighestTrackableValue {
+		v = HighestTrackableValue
+	}
+	h.counts[bucketIndex(v)]++
+}
+
+// Merge adds the values counted by another histogram
+func (h *Histogram) Merge(other *Histogram) {
+	if other.total == 0 {
+		return
+	}
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+	h.total += other.total
+	h.sum += other.sum
+	if other.min < h.min {
+		h.min = other.min
+	}
+	if other.max > h.max {
+		h.max = other.max
+	}
+}
+
+// Reset removes all the counted values
+func (h *Histogram) Reset() {
+	clear(h.counts)
+	h.total = 0
+	h.sum = 0
+	h.min = math.MaxInt64
+	h.max = 0
+}
+
+// Count returns the number of recorded values
+func (h *Histogram) Count() int64 {
+	return h.total
+}
+
+// Min returns the smallest recorded value, or 0 if the histogram is empty
+func (h *Histogram) Min() int64 {
+	if h.total == 0 {
+		return 0
+	}
+	return h.min
+}
+
+// Max returns the largest recorded value
+func (h *Histogram) Max() int64 {
+	return h.max
+}
+
+// Mean returns the average of the recorded values, or 0 if the histogram is empty
+func (h *Histogram) Mean() float64 {
+	if h.total == 0 {
+		return 0
+	}
+	return h.sum / float64(h.total)
+}
+
+// ValueAt returns the value below which the given percentage of the recorded values falls, for example
+// ValueAt(99.9). The value is the upper bound of the bucket, but never more than the largest recorded value.
+func (h *Histogram) ValueAt(percentile float64) int64 {
+	if h.total == 0 {
+		return 0
+	}
+	percentile = math.Min(math.Max(percentile, 0), 100)
+	rank := int64(math.Ceil(percentile / 100 * float64(h.total)))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen int64
+	for i, c := range h.counts {
+		seen += c
+		if seen >= rank {
+			return min(bucketHighestValue(i), h.max)
+		}
+	}
+	return h.max
+}
@@ -0,0 +1,125 @@
+package hdr
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestHistogram_ValueAt(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []int64
+		percentile float64
+		want       int64
+	}{
+		{name: "empty", percentile: 50, want: 0},
+		{name: "single value", values: []int64{42}, percentile: 99, want: 42},
+		{name: "small values are exact", values: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, percentile: 50, want: 5},
+		{name: "p90 of small values", values: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, percentile: 90, want: 9},
+		{name: "p100 is the max", values: []int64{10, 20, 1000003}, percentile: 100, want: 1000003},
+		{name: "p0 is the lowest bucket", values: []int64{3, 20, 1000}, percentile: 0, want: 3},
+		{name: "negative values are counted as 0", values: []int64{-5, -1, 7}, percentile: 50, want: 0},
+		{name: "huge values are clamped", values: []int64{math.MaxInt64}, percentile: 50, want: HighestTrackableValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New()
+			for _, v := range tt.values {
+				h.Record(v)
+			}
+			if got := h.ValueAt(tt.percentile); got != tt.want {
+				t.Errorf("ValueAt(%v) got: %d, want: %d", tt.percentile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistogram_ValueAt_relativeError(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	h := New()
+	values := make([]int64, 100000)
+	for i := range values {
+		// log-normal like the latencies: most values are around 1µs with a long tail
+		values[i] = int64(math.Exp(rnd.NormFloat64()*1.5 + 7))
+		h.Record(values[i])
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	for _, p := range []float64{50, 90, 99, 99.9, 99.99} {
+		want := values[int(math.Ceil(p/100*float64(len(values))))-1]
+		got := h.ValueAt(p)
+		if got < want || float64(got-want) > float64(want)/subBucketCount {
+			t.Errorf("ValueAt(%v) got: %d, want: %d with a relative error lower than %v", p, got, want, 1.0/subBucketCount)
+		}
+	}
+	if h.Max() != values[len(values)-1] {
+		t.Errorf("Max() got: %d, want: %d", h.Max(), values[len(values)-1])
+	}
+	if h.Min() != values[0] {
+		t.Errorf("Min() got: %d, want: %d", h.Min(), values[0])
+	}
+}
+
+func TestHistogram_Merge(t *testing.T) {
+	h1 := New()
+	h2 := New()
+	for v := int64(1); v <= 100; v++ {
+		if v%2 == 0 {
+			h1.Record(v)
+		} else {
+			h2.Record(v)
+		}
+	}
+	h1.Merge(h2)
+	h1.Merge(New())
+
+	if h1.Count() != 100 {
+		t.Errorf("Count() got: %d, want: 100", h1.Count())
+	}
+	if h1.Min() != 1 || h1.Max() != 100 {
+		t.Errorf("Min(), Max() got: %d, %d, want: 1, 100", h1.Min(), h1.Max())
+	}
+	if h1.Mean() != 50.5 {
+		t.Errorf("Mean() got: %v, want: 50.5", h1.Mean())
+	}
+	if got := h1.ValueAt(50); got != 50 {
+		t.Errorf("ValueAt(50) got: %d, want: 50", got)
+	}
+}
+
+func TestHistogram_Reset(t *testing.T) {
+	h := New()
+	h.Record(1000)
+	h.Reset()
+	if h.Count() != 0 || h.Min() != 0 || h.Max() != 0 || h.ValueAt(50) != 0 {
+		t.Errorf("the histogram is not empty after Reset: count %d, min %d, max %d", h.Count(), h.Min(), h.Max())
+	}
+	h.Record(7)
+	if h.Min() != 7 {
+		t.Errorf("Min() got: %d, want: 7", h.Min())
+	}
+}
+
+func TestBucketIndex(t *testing.T) {
+	for _, v := range []int64{0, 1, 127, 128, 129, 255, 256, 257, 1000, 123456789, HighestTrackableValue} {
+		i := bucketIndex(v)
+		if high := bucketHighestValue(i); high < v {
+			t.Errorf("the bucket %d of the value %d ends at %d", i, v, high)
+		}
+		if i > 0 {
+			if low := bucketHighestValue(i-1) + 1; low > v {
+				t.Errorf("the bucket %d of the value %d starts at %d", i, v, low)
+			}
+		}
+	}
+}
+
+func BenchmarkHistogram_Record(b *testing.B) {
+	h := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h.Record(int64(i & 0xfffff))
+	}
+}
@@ -0,0 +1,309 @@
+// Package openapi reads the operations of an OpenAPI 3 specification, so the routers can be benchmarked on the paths
+// of a real API. Every operation becomes a method and a path template with the path parameters declared as {name},
+// together with an example value for each parameter, taken from the specification or generated from its schema.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Operation is an operation of the specification
+type Operation struct {
+	Method string
+	// Path is the path template prefixed by the path of the first server, with the path parameters declared as {name}
+	Path string
+	// Values contains an example value for every path parameter, made of unreserved URL characters only
+	Values map[string]string
+}
+
+// Spec contains the operations of a specification, sorted by path and then by method
+type Spec struct {
+	Title      string
+	Operations []Operation
+	// Skipped describes the operations that cannot be benchmarked, like the ones with a parameter in the middle
+	// of a path segment
+	Skipped []string
+}
+
+// methods are the operation methods of a path item, in the order of the operations
+var methods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+type document struct {
+	OpenAPI string `json:"openapi" yaml:"openapi"`
+	Info    struct {
+		Title string `json:"title" yaml:"title"`
+	} `json:"info" yaml:"info"`
+	Servers    []server            `json:"servers" yaml:"servers"`
+	Paths      map[string]pathItem `json:"paths" yaml:"paths"`
+	Components struct {
+		Parameters map[string]*parameter `json:"parameters" yaml:"parameters"`
+		Schemas    map[string]*schema    `json:"schemas" yaml:"schemas"`
+	} `json:"components" yaml:"components"`
+}
+
+type server struct {
+	URL       string `json:"url" yaml:"url"`
+	Variables map[string]struct {
+		Default string `json:"default" yaml:"default"`
+	} `json:"variables" yaml:"variables"`
+}
+
+type pathItem struct {
+	Parameters []*parameter `json:"parameters" yaml:"parameters"`
+	Get        *operation   `json:"get" yaml:"get"`
+	Head       *operation   `json:"head" yaml:"head"`
+	Post       *operation   `json:"post" yaml:"post"`
+	Put        *operation   `json:"put" yaml:"put"`
+	Patch      *operation   `json:"patch" yaml:"patch"`
+	Delete     *operation   `json:"delete" yaml:"delete"`
+	Options    *operation   `json:"options" yaml:"options"`
+}
+
+type operation struct {
+	Parameters []*parameter `json:"parameters" yaml:"parameters"`
+}
+
+type parameter struct {
+	Ref      string `json:"$ref" yaml:"$ref"`
+	Name     string `json:"name" yaml:"name"`
+	In       string `json:"in" yaml:"in"`
+	Example  any    `json:"example" yaml:"example"`
+	Examples map[string]struct {
+		Value any `json:"value" yaml:"value"`
+	} `json:"examples" yaml:"examples"`
+	Schema *schema `json:"schema" yaml:"schema"`
+}
+
+type schema struct {
+	Ref string `json:"$ref" yaml:"$ref"`
+	// Type is a string, or an array of strings since OpenAPI 3.1
+	Type    any    `json:"type" yaml:"type"`
+	Format  string `json:"format" yaml:"format"`
+	Enum    []any  `json:"enum" yaml:"enum"`
+	Default any    `json:"default" yaml:"default"`
+	Example any    `json:"example" yaml:"example"`
+}
+
+// Load reads the specification file, as JSON if its extension is .json, as YAML otherwise
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := Parse(data, filepath.Ext(path) == ".json")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse parses a specification in JSON or in YAML
+func Parse(data []byte, isJSON bool) (*Spec, error) {
+	var doc document
+	var err error
+	if isJSON {
+		err = json.Unmarshal(data, &doc)
+	} else {
+		err = yaml.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, only OpenAPI 3 is supported", doc.OpenAPI)
+	}
+
+	spec := &Spec{Title: doc.Info.Title}
+	basePath := doc.basePath()
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		item := doc.Paths[p]
+		operations := []*operation{item.Get, item.Head, item.Post, item.Put, item.Patch, item.Delete, item.Options}
+		for i, op := range operations {
+			if op == nil {
+				continue
+			}
+			o, err := doc.operation(methods[i], basePath+p, item.Parameters, op.Parameters)
+			if err != nil {
+				spec.Skipped = append(spec.Skipped, fmt.Sprintf("%s %s: %v", methods[i], p, err))
+				continue
+			}
+			spec.Operations = append(spec.Operations, o)
+		}
+	}
+	if len(spec.Operations) == 0 {
+		return nil, fmt.Errorf("no operation found")
+	}
+	return spec, nil
+}
+
+// basePath returns the path of the URL of the first server, where the server variables are replaced by their default
+// value, without the trailing slash
+func (d *document) basePath() string {
+	if len(d.Servers) == 0 {
+		return ""
+	}
+	s := d.Servers[0]
+	u := s.URL
+	for name, v := range s.Variables {
+		u = strings.ReplaceAll(u, "{"+name+"}", v.Default)
+	}
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+		if j := strings.IndexByte(u, '/'); j >= 0 {
+			u = u[j:]
+		} else {
+			u = ""
+		}
+	}
+	return strings.TrimSuffix(u, "/")
+}
+
+func (d *document) operation(method, path string, pathParams, opParams []*parameter) (Operation, error) {
+	if !strings.HasPrefix(path, "/") {
+		return Operation{}, fmt.Errorf("the path doesn't start with /")
+	}
+	// the parameters of the operation override the ones of the path with the same name and location
+	params := map[string]*parameter{}
+	for _, list := range [][]*parameter{pathParams, opParams} {
+		for _, p := range list {
+			p, err := d.resolveParameter(p)
+			if err != nil {
+				return Operation{}, err
+			}
+			if p.In == "path" {
+				params[p.Name] = p
+			}
+		}
+	}
+
+	o := Operation{Method: method, Path: path, Values: map[string]string{}}
+	index := 0
+	for _, segment := range strings.Split(path, "/") {
+		open, close := strings.IndexByte(segment, '{'), strings.IndexByte(segment, '}')
+		if open < 0 && close < 0 {
+			continue
+		}
+		if open != 0 || close != len(segment)-1 {
+			return Operation{}, fmt.Errorf("the segment %s is not a single path parameter", segment)
+		}
+		name := segment[1 : len(segment)-1]
+		o.Values[name] = d.exampleValue(name, params[name], index)
+		index++
+	}
+	return o, nil
+}
+
+func (d *document) resolveParameter(p *parameter) (*parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, found := strings.CutPrefix(p.Ref, "#/components/parameters/")
+	if resolved := d.Components.Parameters[name]; found && resolved != nil {
+		return resolved, nil
+	}
+	return nil, fmt.Errorf("unresolved parameter reference %s", p.Ref)
+}
+
+func (d *document) resolveSchema(s *schema) *schema {
+	for depth := 0; s != nil && s.Ref != "" && depth < 10; depth++ {
+		name, _ := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		s = d.Components.Schemas[name]
+	}
+	return s
+}
+
+// exampleValue returns the first usable value among the examples of the parameter, the example, the first enum value
+// and the default of its schema, or generates a value from the schema type. The index of the parameter in the path
+// makes the generated values of a path different from each other.
+func (d *document) exampleValue(name string, p *parameter, index int) string {
+	var s *schema
+	if p != nil {
+		if v, ok := urlValue(p.Example); ok {
+			return v
+		}
+		keys := make([]string, 0, len(p.Examples))
+		for k := range p.Examples {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if v, ok := urlValue(p.Examples[k].Value); ok {
+				return v
+			}
+		}
+		s = d.resolveSchema(p.Schema)
+	}
+	if s == nil {
+		return name + "-value"
+	}
+	candidates := []any{s.Example}
+	if len(s.Enum) > 0 {
+		candidates = append(candidates, s.Enum[0])
+	}
+	for _, c := range append(candidates, s.Default) {
+		if v, ok := urlValue(c); ok {
+			return v
+		}
+	}
+
+	switch schemaType(s.Type) {
+	case "integer", "number":
+		return strconv.Itoa(1001 + index)
+	case "boolean":
+		return "true"
+	}
+	switch s.Format {
+	case "uuid":
+		return fmt.Sprintf("3fa85f64-5717-4562-b3fc-2c963f66afa%d", index%10)
+	case "date":
+		return fmt.Sprintf("2024-03-%02d", 1+index%28)
+	case "email":
+		return fmt.Sprintf("user%d@example.com", index+1)
+	}
+	return name + "-value"
+}
+
+// schemaType returns the type of a schema, the first one that is not null for the OpenAPI 3.1 type arrays
+func schemaType(t any) string {
+	switch t := t.(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// urlValue formats an example value, it returns false if there is no value or if it contains characters that would
+// be escaped in a URL path
+func urlValue(v any) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	s := fmt.Sprint(v)
+	if s == "" {
+		return "", false
+	}
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("-._~@", c)) {
+			return "", false
+		}
+	}
+	return s, true
+}
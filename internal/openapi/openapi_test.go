@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	want := []Operation{
+		{Method: "GET", Path: "/v1/pets", Values: map[string]string{}},
+		{Method: "POST", Path: "/v1/pets", Values: map[string]string{}},
+		{Method: "GET", Path: "/v1/pets/{petId}", Values: map[string]string{"petId": "1001"}},
+		{Method: "PUT", Path: "/v1/pets/{petId}", Values: map[string]string{"petId": "1001"}},
+		{Method: "DELETE", Path: "/v1/pets/{petId}", Values: map[string]string{"petId": "1001"}},
+		{Method: "GET", Path: "/v1/pets/{petId}/photos/{photoId}", Values: map[string]string{"petId": "1001", "photoId": "3fa85f64-5717-4562-b3fc-2c963f66afa1"}},
+		{Method: "GET", Path: "/v1/stores/{storeId}/orders/{status}", Values: map[string]string{"storeId": "store-42", "status": "placed"}},
+		// the example with a / is not usable in a path segment
+		{Method: "GET", Path: "/v1/users/{userId}", Values: map[string]string{"userId": "admin"}},
+	}
+	for _, file := range []string{"testdata/petstore.yaml", "testdata/petstore.json"} {
+		t.Run(file, func(t *testing.T) {
+			spec, err := Load(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if spec.Title != "Petstore" {
+				t.Errorf("Load() got title: %q, want: Petstore", spec.Title)
+			}
+			if !reflect.DeepEqual(spec.Operations, want) {
+				t.Errorf("Load() got operations:\n%+v\nwant:\n%+v", spec.Operations, want)
+			}
+			if len(spec.Skipped) != 1 || !strings.Contains(spec.Skipped[0], "/files/{name}.{extension}") {
+				t.Errorf("Load() got skipped: %q, want the /files/{name}.{extension} operation", spec.Skipped)
+			}
+		})
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{name: "swagger 2", spec: "swagger: '2.0'\npaths:\n  /pets:\n    get: {}\n", want: "only OpenAPI 3"},
+		{name: "no operation", spec: "openapi: 3.0.0\npaths: {}\n", want: "no operation"},
+		{name: "invalid yaml", spec: "openapi: [3.0.0\n", want: "yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.spec), false)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() got error: %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_unresolvedReference(t *testing.T) {
+	spec, err := Parse([]byte(`{"openapi": "3.1.0", "paths": {
+		"/a/{id}": {"get": {"parameters": [{"$ref": "#/components/parameters/Missing"}]}},
+		"/b/{id}": {"get": {"parameters": [{"name": "id", "in": "path", "schema": {"type": ["integer", "null"]}}]}}
+	}}`), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Operation{{Method: "GET", Path: "/b/{id}", Values: map[string]string{"id": "1001"}}}
+	if !reflect.DeepEqual(spec.Operations, want) {
+		t.Errorf("Parse() got operations: %+v, want: %+v", spec.Operations, want)
+	}
+	if len(spec.Skipped) != 1 || !strings.Contains(spec.Skipped[0], "unresolved parameter reference") {
+		t.Errorf("Parse() got skipped: %q, want the unresolved reference", spec.Skipped)
+	}
+}
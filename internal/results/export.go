@@ -0,0 +1,110 @@
+package results
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// standardUnits are the units reported by the testing package, they are the first metric columns of the CSV
+var standardUnits = []string{"ns/op", "B/op", "allocs/op"}
+
+// WriteFile creates the file and writes into it, the path - is the standard output
+func WriteFile(path string, write func(w io.Writer) error) error {
+	if path == "-" {
+		return write(os.Stdout)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// WriteJSON writes the results as an indented JSON array
+func WriteJSON(w io.Writer, results []*Result) error {
+	if results == nil {
+		results = []*Result{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// ReadJSON reads the results written by WriteJSON
+func ReadJSON(r io.Reader) ([]*Result, error) {
+	var results []*Result
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// WriteCSV writes the results as CSV, with a header row. After the environment columns, there is one column per metric
+// unit reported by at least one result: ns/op, B/op and allocs/op first, then the custom units in alphabetical order.
+// The cell of a metric that a result doesn't report is empty.
+func WriteCSV(w io.Writer, results []*Result) error {
+	units := metricUnits(results)
+	header := []string{"scenario", "framework", "framework_version", "route_set", "gomaxprocs", "cpu", "go_version", "os", "arch", "iterations"}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append(header, units...)); err != nil {
+		return err
+	}
+	for _, r := range results {
+		row := []string{
+			r.Scenario,
+			r.Framework,
+			r.FrameworkVersion,
+			r.RouteSet,
+			strconv.Itoa(r.GOMAXPROCS),
+			r.CPU,
+			r.GoVersion,
+			r.OS,
+			r.Arch,
+			strconv.FormatInt(r.Iterations, 10),
+		}
+		for _, unit := range units {
+			if v, found := r.Metrics[unit]; found {
+				row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+			} else {
+				row = append(row, "")
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// metricUnits returns the units reported by the results, in the order of the CSV columns
+func metricUnits(results []*Result) []string {
+	seen := map[string]bool{}
+	for _, r := range results {
+		for unit := range r.Metrics {
+			seen[unit] = true
+		}
+	}
+	var units []string
+	for _, unit := range standardUnits {
+		if seen[unit] {
+			units = append(units, unit)
+			delete(seen, unit)
+		}
+	}
+	custom := make([]string, 0, len(seen))
+	for unit := range seen {
+		custom = append(custom, unit)
+	}
+	sort.Strings(custom)
+	return append(units, custom...)
+}
@@ -0,0 +1,169 @@
+// Package results parses the output of the benchmarks, in the Go benchmark format, into results that carry the
+// environment of the run, and exports them as JSON or CSV.
+//
+// The environment is read from the configuration lines ("key: value") of the output: goos, goarch and cpu are
+// printed by the testing package, go, routeset and framework-version by the benchmarks themselves.
+// A configuration line applies to all the results printed after it. GOMAXPROCS is read from the -N suffix of the
+// benchmark name, which the testing package omits when GOMAXPROCS is 1.
+package results
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// IterationsUnit is the unit of the metric that, when reported, replaces the number of iterations of the result
+const IterationsUnit = "iterations"
+
+// Result is the outcome of a sub-benchmark, for example Benchmark_VarCapture/gofre
+type Result struct {
+	// Scenario is the benchmark name without the Benchmark_ prefix, for example VarCapture_Concurrent
+	Scenario string `json:"scenario"`
+	// Framework is the sub-benchmark name, for example gofre or fasthttp/fiber
+	Framework        string `json:"framework"`
+	FrameworkVersion string `json:"framework_version"`
+	RouteSet         string `json:"route_set"`
+	GOMAXPROCS       int    `json:"gomaxprocs"`
+	CPU              string `json:"cpu"`
+	GoVersion        string `json:"go_version"`
+	OS               string `json:"os"`
+	Arch             string `json:"arch"`
+	Iterations       int64  `json:"iterations"`
+	// Metrics contains the values reported by the benchmark, by unit: ns/op, B/op, allocs/op and the custom metrics
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+// Parse reads the benchmark output and returns the results in the order they were printed.
+// The lines that are neither results nor configuration lines, like PASS or the log of the benchmarks, are ignored.
+func Parse(r io.Reader) ([]*Result, error) {
+	config := map[string]string{}
+	var results []*Result
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.HasPrefix(text, "Benchmark") {
+			res, err := parseResult(text, config)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if res != nil {
+				results = append(results, res)
+			}
+			continue
+		}
+		if key, value, found := parseConfig(text); found {
+			config[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ReadFiles reads the results of the files, in order: the files with the .json extension contain the JSON written by
+// WriteJSON, the other ones the benchmark output. The path - is the standard input, which is also read if there are
+// no paths.
+func ReadFiles(paths ...string) ([]*Result, error) {
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	var all []*Result
+	for _, path := range paths {
+		res, err := readFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		all = append(all, res...)
+	}
+	return all, nil
+}
+
+func readFile(path string) ([]*Result, error) {
+	if path == "-" {
+		return Parse(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if filepath.Ext(path) == ".json" {
+		return ReadJSON(f)
+	}
+	return Parse(f)
+}
+
+// parseConfig parses a configuration line: a key starting with a lower case letter and without spaces,
+// followed by a colon and the value
+func parseConfig(line string) (key, value string, found bool) {
+	key, value, found = strings.Cut(line, ":")
+	if !found || key == "" || !unicode.IsLower(rune(key[0])) || strings.ContainsFunc(key, unicode.IsSpace) {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(value), true
+}
+
+// parseResult parses a result line: the benchmark name, the number of iterations and the value unit pairs.
+// It returns nil for the lines that only start with a benchmark name, like the name printed before a failure.
+func parseResult(line string, config map[string]string) (*Result, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || len(fields)%2 != 0 {
+		return nil, nil
+	}
+	iterations, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+
+	name, procs := splitProcs(fields[0])
+	scenario, framework, _ := strings.Cut(strings.TrimPrefix(name, "Benchmark"), "/")
+	res := &Result{
+		Scenario:         strings.TrimPrefix(scenario, "_"),
+		Framework:        framework,
+		FrameworkVersion: config["framework-version"],
+		RouteSet:         config["routeset"],
+		CPU:              config["cpu"],
+		GoVersion:        config["go"],
+		OS:               config["goos"],
+		Arch:             config["goarch"],
+		Iterations:       iterations,
+		Metrics:          make(map[string]float64, (len(fields)-2)/2),
+	}
+	res.GOMAXPROCS = procs
+
+	for i := 2; i < len(fields); i += 2 {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of the %s metric: %w", fields[i], fields[i+1], err)
+		}
+		res.Metrics[fields[i+1]] = v
+	}
+	// a benchmark run in a child process reports the iterations of the child as a metric
+	if v, found := res.Metrics[IterationsUnit]; found {
+		res.Iterations = int64(v)
+		delete(res.Metrics, IterationsUnit)
+	}
+	return res, nil
+}
+
+// splitProcs removes the -N suffix added to the benchmark name by the testing package when GOMAXPROCS is not 1
+// and returns it, or 1 if the name doesn't have it
+func splitProcs(name string) (string, int) {
+	i := strings.LastIndexByte(name, '-')
+	if i < 0 {
+		return name, 1
+	}
+	procs, err := strconv.Atoi(name[i+1:])
+	if err != nil || procs <= 0 {
+		return name, 1
+	}
+	return name[:i], procs
+}
@@ -0,0 +1,156 @@
+package results
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const benchOutput = `go: go1.22.5
+routeset: varcapture
+framework-version: v1.1.0
+goos: linux
+goarch: amd64
+pkg: github.com/ixtendio/gofrebench
+cpu: Intel(R) Xeon(R) Processor
+Benchmark_VarCapture/gofre-8         	 1260130	       937.4 ns/op	     27588 max-ns	       459.0 p50-ns	      64 B/op	       1 allocs/op
+framework-version: v4.9.1
+Benchmark_VarCapture/echo-8          	--- SKIP: Benchmark_VarCapture/echo
+    conformance_test.go:64: echo does not support the varcapture routes
+routeset: static
+framework-version: v1.8.0
+Benchmark_Loopback_Static/fasthttp/fiber-8 	    3000	     35702 ns/op	     28009 req/s
+PASS
+ok  	github.com/ixtendio/gofrebench	2.683s
+`
+
+func TestParse(t *testing.T) {
+	got, err := Parse(strings.NewReader(benchOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Result{
+		{
+			Scenario:         "VarCapture",
+			Framework:        "gofre",
+			FrameworkVersion: "v1.1.0",
+			RouteSet:         "varcapture",
+			GOMAXPROCS:       8,
+			CPU:              "Intel(R) Xeon(R) Processor",
+			GoVersion:        "go1.22.5",
+			OS:               "linux",
+			Arch:             "amd64",
+			Iterations:       1260130,
+			Metrics:          map[string]float64{"ns/op": 937.4, "max-ns": 27588, "p50-ns": 459, "B/op": 64, "allocs/op": 1},
+		},
+		{
+			Scenario:         "Loopback_Static",
+			Framework:        "fasthttp/fiber",
+			FrameworkVersion: "v1.8.0",
+			RouteSet:         "static",
+			GOMAXPROCS:       8,
+			CPU:              "Intel(R) Xeon(R) Processor",
+			GoVersion:        "go1.22.5",
+			OS:               "linux",
+			Arch:             "amd64",
+			Iterations:       3000,
+			Metrics:          map[string]float64{"ns/op": 35702, "req/s": 28009},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.Marshal(got)
+		wantJSON, _ := json.Marshal(want)
+		t.Errorf("Parse() got: %s, want: %s", gotJSON, wantJSON)
+	}
+}
+
+func TestParse_gomaxprocs(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		want      int
+		framework string
+	}{
+		{name: "from the name suffix", output: "Benchmark_Static/gin-4 100 10 ns/op", want: 4, framework: "gin"},
+		// the testing package omits the suffix when GOMAXPROCS is 1, whatever the GOMAXPROCS of the process
+		{name: "without suffix", output: "Benchmark_Static/gin 100 10 ns/op", want: 1, framework: "gin"},
+		{name: "with a framework path", output: "Benchmark_Static/fasthttp/fiber-2 100 10 ns/op", want: 2, framework: "fasthttp/fiber"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.output))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("Parse() got %d results, want: 1", len(got))
+			}
+			if got[0].GOMAXPROCS != tt.want || got[0].Framework != tt.framework {
+				t.Errorf("Parse() got: %d %s, want: %d %s", got[0].GOMAXPROCS, got[0].Framework, tt.want, tt.framework)
+			}
+		})
+	}
+}
+
+func TestParse_iterationsMetric(t *testing.T) {
+	got, err := Parse(strings.NewReader("Benchmark_Static/gin 1 812.5 ns/op 1450000 iterations"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Parse() got %d results, want: 1", len(got))
+	}
+	want := map[string]float64{"ns/op": 812.5}
+	if got[0].Iterations != 1450000 || !reflect.DeepEqual(got[0].Metrics, want) {
+		t.Errorf("Parse() got: %d %v, want: 1450000 %v", got[0].Iterations, got[0].Metrics, want)
+	}
+}
+
+func TestParse_invalidValue(t *testing.T) {
+	if _, err := Parse(strings.NewReader("Benchmark_Static/gin 100 fast ns/op")); err == nil {
+		t.Error("Parse() expected an error for an invalid value")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	results := []*Result{
+		{Scenario: "Static", Framework: "gin", GOMAXPROCS: 1, Iterations: 100, Metrics: map[string]float64{"ns/op": 10.5, "allocs/op": 0, "p50-ns": 9}},
+		{Scenario: "Loopback_Static", Framework: "gofre", GOMAXPROCS: 1, Iterations: 50, Metrics: map[string]float64{"ns/op": 1000, "req/s": 25000.25}},
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	want := `scenario,framework,framework_version,route_set,gomaxprocs,cpu,go_version,os,arch,iterations,ns/op,allocs/op,p50-ns,req/s
+Static,gin,,,1,,,,,100,10.5,0,9,
+Loopback_Static,gofre,,,1,,,,,50,1000,,,25000.25
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteCSV() got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteJSON_ReadJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("WriteJSON() got: %s, want: []", got)
+	}
+
+	results := []*Result{{Scenario: "Static", Framework: "gin", Metrics: map[string]float64{"ns/op": 10.5}}}
+	buf.Reset()
+	if err := WriteJSON(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, results) {
+		t.Errorf("ReadJSON() got: %v, want: %v", got, results)
+	}
+}
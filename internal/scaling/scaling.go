@@ -0,0 +1,193 @@
+// Package scaling computes how the throughput of the frameworks scales with GOMAXPROCS, from the results of
+// a concurrent scenario run with several -cpu values.
+//
+// The speedup at P procs is the throughput at P divided by the throughput at the smallest GOMAXPROCS of the sweep,
+// usually 1, and the efficiency is the speedup divided by the ratio of the procs: a framework without contention keeps
+// an efficiency close to 100%, while a lock or a shared counter in the router makes it drop as the procs grow.
+package scaling
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/ixtendio/gofrebench/internal/results"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Point is the throughput of a framework at a GOMAXPROCS value
+type Point struct {
+	GOMAXPROCS int
+	// Throughput is in requests per second, the mean of the samples
+	Throughput float64
+	Speedup    float64
+	Efficiency float64
+}
+
+// Curve is the throughput of a framework in a scenario across the GOMAXPROCS sweep, sorted by GOMAXPROCS
+type Curve struct {
+	Scenario  string
+	Framework string
+	Points    []Point
+}
+
+// Curves returns the scaling curves of the results, in the order of the first result of each scenario and framework.
+// The throughput of a result is its req/s metric, or computed from ns/op, which is the wall time per request in the
+// concurrent benchmarks. The results without any of them are ignored.
+func Curves(res []*results.Result) []*Curve {
+	type key struct {
+		scenario  string
+		framework string
+	}
+	type sum struct {
+		total float64
+		n     int
+	}
+	var keys []key
+	samples := map[key]map[int]*sum{}
+	for _, r := range res {
+		throughput, found := Throughput(r)
+		if !found {
+			continue
+		}
+		k := key{scenario: r.Scenario, framework: r.Framework}
+		procs, found := samples[k]
+		if !found {
+			keys = append(keys, k)
+			procs = map[int]*sum{}
+			samples[k] = procs
+		}
+		s, found := procs[r.GOMAXPROCS]
+		if !found {
+			s = &sum{}
+			procs[r.GOMAXPROCS] = s
+		}
+		s.total += throughput
+		s.n++
+	}
+
+	curves := make([]*Curve, 0, len(keys))
+	for _, k := range keys {
+		c := &Curve{Scenario: k.scenario, Framework: k.framework}
+		for procs, s := range samples[k] {
+			c.Points = append(c.Points, Point{GOMAXPROCS: procs, Throughput: s.total / float64(s.n)})
+		}
+		sort.Slice(c.Points, func(i, j int) bool { return c.Points[i].GOMAXPROCS < c.Points[j].GOMAXPROCS })
+		base := c.Points[0]
+		for i := range c.Points {
+			p := &c.Points[i]
+			if base.Throughput > 0 {
+				p.Speedup = p.Throughput / base.Throughput
+			}
+			if base.GOMAXPROCS > 0 && p.GOMAXPROCS > 0 {
+				p.Efficiency = p.Speedup / (float64(p.GOMAXPROCS) / float64(base.GOMAXPROCS))
+			}
+		}
+		curves = append(curves, c)
+	}
+	return curves
+}
+
+// Throughput returns the requests per second of a result
+func Throughput(r *results.Result) (float64, bool) {
+	if v, found := r.Metrics["req/s"]; found {
+		return v, true
+	}
+	if v := r.Metrics["ns/op"]; v > 0 {
+		return 1e9 / v, true
+	}
+	return 0, false
+}
+
+// WriteTable writes one table per scenario, with one row per framework and one column per GOMAXPROCS value.
+// A cell contains the throughput and the efficiency.
+func WriteTable(w io.Writer, curves []*Curve) error {
+	var scenarios []string
+	byScenario := map[string][]*Curve{}
+	for _, c := range curves {
+		if _, found := byScenario[c.Scenario]; !found {
+			scenarios = append(scenarios, c.Scenario)
+		}
+		byScenario[c.Scenario] = append(byScenario[c.Scenario], c)
+	}
+
+	for i, scenario := range scenarios {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s\n", scenario)
+		procs := sweep(byScenario[scenario])
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprint(tw, "framework\t")
+		for _, p := range procs {
+			fmt.Fprintf(tw, "GOMAXPROCS=%d\t", p)
+		}
+		fmt.Fprintln(tw)
+		for _, c := range byScenario[scenario] {
+			fmt.Fprintf(tw, "%s\t", c.Framework)
+			for _, p := range procs {
+				if point, found := c.point(p); found {
+					fmt.Fprintf(tw, "%.0f req/s %3.0f%%\t", point.Throughput, point.Efficiency*100)
+				} else {
+					fmt.Fprint(tw, "-\t")
+				}
+			}
+			fmt.Fprintln(tw)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes a row per point of the curves, with a header row
+func WriteCSV(w io.Writer, curves []*Curve) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"scenario", "framework", "gomaxprocs", "req/s", "speedup", "efficiency"}); err != nil {
+		return err
+	}
+	for _, c := range curves {
+		for _, p := range c.Points {
+			row := []string{
+				c.Scenario,
+				c.Framework,
+				strconv.Itoa(p.GOMAXPROCS),
+				strconv.FormatFloat(p.Throughput, 'f', 0, 64),
+				strconv.FormatFloat(p.Speedup, 'f', 3, 64),
+				strconv.FormatFloat(p.Efficiency, 'f', 3, 64),
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (c *Curve) point(procs int) (Point, bool) {
+	for _, p := range c.Points {
+		if p.GOMAXPROCS == procs {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// sweep returns the sorted GOMAXPROCS values of the curves
+func sweep(curves []*Curve) []int {
+	seen := map[int]bool{}
+	var procs []int
+	for _, c := range curves {
+		for _, p := range c.Points {
+			if !seen[p.GOMAXPROCS] {
+				seen[p.GOMAXPROCS] = true
+				procs = append(procs, p.GOMAXPROCS)
+			}
+		}
+	}
+	sort.Ints(procs)
+	return procs
+}
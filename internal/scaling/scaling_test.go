@@ -0,0 +1,84 @@
+package scaling
+
+import (
+	"bytes"
+	"github.com/ixtendio/gofrebench/internal/results"
+	"math"
+	"strings"
+	"testing"
+)
+
+const sweepOutput = `Benchmark_VarCapture_Concurrent/gofre    	 1000000	      1000 ns/op
+Benchmark_VarCapture_Concurrent/gofre-2  	 2000000	       550 ns/op
+Benchmark_VarCapture_Concurrent/gofre-2  	 2000000	       450 ns/op
+Benchmark_VarCapture_Concurrent/gofre-4  	 4000000	       500 ns/op
+Benchmark_VarCapture_Concurrent/gin      	 1000000	      2000 ns/op
+Benchmark_VarCapture_Concurrent/gin-4    	 1000000	      1000 ns/op
+Benchmark_Loopback_Static/gofre-2        	    3000	     35702 ns/op	     30000 req/s
+Benchmark_Loopback_Static/gofre-4        	    3000	     35702 ns/op	     45000 req/s
+`
+
+func TestCurves(t *testing.T) {
+	res, err := results.Parse(strings.NewReader(sweepOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	curves := Curves(res)
+	want := []*Curve{
+		{Scenario: "VarCapture_Concurrent", Framework: "gofre", Points: []Point{
+			{GOMAXPROCS: 1, Throughput: 1e6, Speedup: 1, Efficiency: 1},
+			// the mean of the throughputs, 1e9/550 and 1e9/450
+			{GOMAXPROCS: 2, Throughput: 2.0202020e6, Speedup: 2.0202020, Efficiency: 1.0101010},
+			{GOMAXPROCS: 4, Throughput: 2e6, Speedup: 2, Efficiency: 0.5},
+		}},
+		{Scenario: "VarCapture_Concurrent", Framework: "gin", Points: []Point{
+			{GOMAXPROCS: 1, Throughput: 5e5, Speedup: 1, Efficiency: 1},
+			{GOMAXPROCS: 4, Throughput: 1e6, Speedup: 2, Efficiency: 0.5},
+		}},
+		// the req/s metric is used when reported, and the smallest GOMAXPROCS is the base of the speedup
+		{Scenario: "Loopback_Static", Framework: "gofre", Points: []Point{
+			{GOMAXPROCS: 2, Throughput: 30000, Speedup: 1, Efficiency: 1},
+			{GOMAXPROCS: 4, Throughput: 45000, Speedup: 1.5, Efficiency: 0.75},
+		}},
+	}
+	if len(curves) != len(want) {
+		t.Fatalf("Curves() got %d curves, want: %d", len(curves), len(want))
+	}
+	for i, c := range curves {
+		w := want[i]
+		if c.Scenario != w.Scenario || c.Framework != w.Framework || len(c.Points) != len(w.Points) {
+			t.Fatalf("Curves()[%d] got: %+v, want: %+v", i, c, w)
+		}
+		for j, p := range c.Points {
+			wp := w.Points[j]
+			if p.GOMAXPROCS != wp.GOMAXPROCS || !near(p.Throughput, wp.Throughput) || !near(p.Speedup, wp.Speedup) || !near(p.Efficiency, wp.Efficiency) {
+				t.Errorf("Curves() %s %s got: %+v, want: %+v", c.Scenario, c.Framework, p, wp)
+			}
+		}
+	}
+}
+
+func near(got, want float64) bool {
+	return math.Abs(got-want) <= 1e-6*math.Max(1, math.Abs(want))
+}
+
+func TestWriteTable(t *testing.T) {
+	res, err := results.Parse(strings.NewReader(sweepOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, Curves(res)); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{"VarCapture_Concurrent\n", "GOMAXPROCS=4", "2000000 req/s  50%", "Loopback_Static\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteTable() got:\n%s\nwant it to contain: %q", got, want)
+		}
+	}
+	// gin was not run with GOMAXPROCS=2
+	if !strings.Contains(got, "-") {
+		t.Errorf("WriteTable() got:\n%s\nwant a - for the missing point", got)
+	}
+}
@@ -0,0 +1,134 @@
+// Package stats implements the statistics used to compare two sets of benchmark samples: the Mann-Whitney U test,
+// which doesn't assume the samples are normally distributed, and the summary of a sample by its median.
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// exactLimit is the largest total number of samples, without ties, for which the exact distribution of U is computed.
+// Above it, or when there are ties, the normal approximation is used.
+const exactLimit = 50
+
+// MannWhitneyUTest returns the two-sided p-value of the Mann-Whitney U test for the samples x and y: the probability,
+// if both samples come from the same distribution, of observing a difference at least as large as the observed one.
+// It returns 1 if one of the samples is empty or if all the values are equal.
+func MannWhitneyUTest(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	u, ties := uStatistic(x, y)
+	if !ties && n1+n2 <= exactLimit {
+		return exactPValue(u, n1, n2)
+	}
+	return normalPValue(u, n1, n2, append(append([]float64(nil), x...), y...))
+}
+
+// uStatistic returns the U statistic of x, computed from the ranks of the merged samples (the tied values get the
+// average of their ranks), and whether there are ties
+func uStatistic(x, y []float64) (float64, bool) {
+	type value struct {
+		v     float64
+		fromX bool
+	}
+	merged := make([]value, 0, len(x)+len(y))
+	for _, v := range x {
+		merged = append(merged, value{v: v, fromX: true})
+	}
+	for _, v := range y {
+		merged = append(merged, value{v: v})
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].v < merged[j].v })
+
+	var rankSumX float64
+	ties := false
+	for i := 0; i < len(merged); {
+		j := i + 1
+		for j < len(merged) && merged[j].v == merged[i].v {
+			j++
+		}
+		if j-i > 1 {
+			ties = true
+		}
+		// the ranks i+1..j have the average (i+1+j)/2
+		rank := float64(i+1+j) / 2
+		for k := i; k < j; k++ {
+			if merged[k].fromX {
+				rankSumX += rank
+			}
+		}
+		i = j
+	}
+	n1 := float64(len(x))
+	return rankSumX - n1*(n1+1)/2, ties
+}
+
+// exactPValue computes the two-sided p-value from the exact distribution of U, valid when there are no ties
+func exactPValue(u float64, n1, n2 int) float64 {
+	// counts[j][k] is the number of arrangements of i values of x and j values of y with U = k, for the current i
+	maxU := n1 * n2
+	counts := make([][]float64, n2+1)
+	for j := range counts {
+		counts[j] = make([]float64, maxU+1)
+		counts[j][0] = 1
+	}
+	for i := 1; i <= n1; i++ {
+		next := make([][]float64, n2+1)
+		for j := 0; j <= n2; j++ {
+			next[j] = make([]float64, maxU+1)
+			for k := 0; k <= i*j; k++ {
+				// the largest value is either from x, which then exceeds the j values of y, or from y
+				if k >= j {
+					next[j][k] += counts[j][k-j]
+				}
+				if j > 0 {
+					next[j][k] += next[j-1][k]
+				}
+			}
+		}
+		counts = next
+	}
+
+	dist := counts[n2]
+	var total, lower, upper float64
+	for k, c := range dist {
+		total += c
+		if float64(k) <= u {
+			lower += c
+		}
+		if float64(k) >= u {
+			upper += c
+		}
+	}
+	return math.Min(1, 2*math.Min(lower, upper)/total)
+}
+
+// normalPValue computes the two-sided p-value with the normal approximation of U, corrected for the ties and
+// for the continuity
+func normalPValue(u float64, n1, n2 int, all []float64) float64 {
+	sort.Float64s(all)
+	var tieCorrection float64
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j] == all[i] {
+			j++
+		}
+		t := float64(j - i)
+		tieCorrection += t*t*t - t
+		i = j
+	}
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	diff := math.Abs(u-mean) - 0.5
+	if diff < 0 {
+		diff = 0
+	}
+	z := diff / math.Sqrt(variance)
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
@@ -0,0 +1,34 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Summary describes a sample by its median and its spread
+type Summary struct {
+	N      int
+	Median float64
+	// Spread is the largest distance between a value and the median, relative to the median
+	Spread float64
+}
+
+// Summarize returns the summary of the sample, the zero Summary if the sample is empty
+func Summarize(sample []float64) Summary {
+	if len(sample) == 0 {
+		return Summary{}
+	}
+	sorted := append([]float64(nil), sample...)
+	sort.Float64s(sorted)
+	var median float64
+	if n := len(sorted); n%2 == 1 {
+		median = sorted[n/2]
+	} else {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	var spread float64
+	if median != 0 {
+		spread = math.Max(median-sorted[0], sorted[len(sorted)-1]-median) / math.Abs(median)
+	}
+	return Summary{N: len(sorted), Median: median, Spread: spread}
+}
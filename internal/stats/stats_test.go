@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMannWhitneyUTest(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+		// the expected p-values without ties are twice the probability of the observed U among the C(n1+n2, n1)
+		// arrangements, the one with ties is the normal approximation with the continuity correction
+		want float64
+	}{
+		{name: "separated samples", x: []float64{1, 2, 3, 4, 5}, y: []float64{6, 7, 8, 9, 10}, want: 0.0079365},
+		{name: "separated samples in reverse order", x: []float64{6, 7, 8, 9, 10}, y: []float64{1, 2, 3, 4, 5}, want: 0.0079365},
+		{name: "interleaved samples", x: []float64{1, 3, 5, 7, 9}, y: []float64{2, 4, 6, 8, 10}, want: 0.6904762},
+		{name: "different sizes", x: []float64{10.1, 10.4, 10.2}, y: []float64{11, 12.5, 11.2, 11.8}, want: 0.0571429},
+		{name: "ties use the normal approximation", x: []float64{1, 2, 2, 3, 3}, y: []float64{3, 4, 4, 5, 5}, want: 0.0188657},
+		{name: "all equal", x: []float64{5, 5, 5}, y: []float64{5, 5, 5}, want: 1},
+		{name: "empty sample", x: nil, y: []float64{1, 2}, want: 1},
+		{name: "single values", x: []float64{1}, y: []float64{2}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MannWhitneyUTest(tt.x, tt.y); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("MannWhitneyUTest() got: %.7f, want: %.7f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMannWhitneyUTest_largeSamples(t *testing.T) {
+	// above exactLimit, the normal approximation is used also without ties
+	var x, y []float64
+	for i := 0; i < 30; i++ {
+		x = append(x, float64(100+i))
+		y = append(y, float64(100+i)+0.5)
+	}
+	if p := MannWhitneyUTest(x, y); p < 0.5 {
+		t.Errorf("MannWhitneyUTest() got: %v for nearly identical samples", p)
+	}
+	for i := range y {
+		y[i] += 50
+	}
+	if p := MannWhitneyUTest(x, y); p > 1e-6 {
+		t.Errorf("MannWhitneyUTest() got: %v for separated samples", p)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample []float64
+		want   Summary
+	}{
+		{name: "empty", want: Summary{}},
+		{name: "odd", sample: []float64{110, 100, 90}, want: Summary{N: 3, Median: 100, Spread: 0.1}},
+		{name: "even", sample: []float64{4, 1, 2, 3}, want: Summary{N: 4, Median: 2.5, Spread: 0.6}},
+		{name: "zero median", sample: []float64{0, 0, 1}, want: Summary{N: 3, Median: 0, Spread: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize(tt.sample)
+			if got.N != tt.want.N || got.Median != tt.want.Median || math.Abs(got.Spread-tt.want.Spread) > 1e-9 {
+				t.Errorf("Summarize() got: %+v, want: %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package router
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/ixtendio/gofrebench/internal/results"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var inProcess = flag.Bool("inprocess", false, "run all the frameworks in the benchmark process, instead of one child process per framework and scenario")
+
+// forwardedTestFlags are the flags of the testing package, among the ones set on the command line, passed to the child
+// processes. All the flags of the benchmarks are passed, except the ones selecting the frameworks.
+var forwardedTestFlags = map[string]bool{
+	"test.benchtime": true,
+	"test.benchmem":  true,
+	"test.short":     true,
+	"test.timeout":   true,
+}
+
+// logLine matches a message logged by a benchmark in the verbose output, like the reason of a skip
+var logLine = regexp.MustCompile(`^\s+\S+\.go:\d+: (.*)$`)
+
+// isolatedResult is the result of the last child process, reused by the calls of the sub-benchmark with a larger b.N
+var isolatedResult struct {
+	name    string
+	metrics map[string]float64
+}
+
+// benchmarkIsolated runs the benchmark of a framework in a child process, a re-execution of the test binary selecting
+// the benchmark and the framework, so the heap and the init-time state of the other frameworks don't affect it.
+// The metrics of the child, ns/op included, are reported as the metrics of the sub-benchmark, and its iterations as
+// the iterations metric, which the results parser uses as the number of iterations.
+//
+// The testing package calls the sub-benchmark first with b.N = 1, which runs the child. The child runs for the whole
+// benchtime, so the testing package doesn't call the sub-benchmark again, unless the benchtime is a number of
+// iterations: the next call reuses the result of the child.
+func benchmarkIsolated(b *testing.B, benchmark, framework string) {
+	if b.N > 1 && isolatedResult.name == b.Name() {
+		reportIsolated(b, isolatedResult.metrics)
+		return
+	}
+	isolatedResult.name, isolatedResult.metrics = "", nil
+
+	exe, err := os.Executable()
+	if err != nil {
+		b.Fatal(err)
+	}
+	args := []string{
+		"-test.run=^$",
+		"-test.bench=^" + regexp.QuoteMeta(benchmark) + "$",
+		"-test.count=1",
+		"-test.cpu=" + strconv.Itoa(runtime.GOMAXPROCS(0)),
+		"-test.v",
+		"-frameworks=" + framework,
+		"-inprocess",
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if forwardedTestFlags[f.Name] || !strings.HasPrefix(f.Name, "test.") && f.Name != "frameworks" && f.Name != "inprocess" {
+			args = append(args, fmt.Sprintf("-%s=%s", f.Name, f.Value))
+		}
+	})
+	var stdout bytes.Buffer
+	cmd := exec.Command(exe, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+
+	res, err := results.Parse(bytes.NewReader(stdout.Bytes()))
+	if err != nil {
+		b.Fatalf("invalid output of the child process: %v\n%s", err, stdout.Bytes())
+	}
+	for _, r := range res {
+		if r.Framework == framework && runErr == nil {
+			r.Metrics[results.IterationsUnit] = float64(r.Iterations)
+			isolatedResult.name, isolatedResult.metrics = b.Name(), r.Metrics
+			reportIsolated(b, r.Metrics)
+			return
+		}
+	}
+	messages := loggedMessages(stdout.String())
+	if runErr != nil {
+		b.Fatalf("child process: %v\n%s", runErr, messages)
+	}
+	if bytes.Contains(stdout.Bytes(), []byte("--- SKIP:")) {
+		b.Skip(messages)
+	}
+	b.Fatalf("no result in the output of the child process:\n%s", stdout.Bytes())
+}
+
+func reportIsolated(b *testing.B, metrics map[string]float64) {
+	if _, found := metrics["allocs/op"]; found {
+		b.ReportAllocs()
+	}
+	for unit, v := range metrics {
+		b.ReportMetric(v, unit)
+	}
+}
+
+// loggedMessages returns the messages logged by the benchmarks in the verbose output
+func loggedMessages(output string) string {
+	var messages []string
+	for _, line := range strings.Split(output, "\n") {
+		if m := logLine.FindStringSubmatch(line); m != nil {
+			messages = append(messages, m[1])
+		}
+	}
+	return strings.Join(messages, "\n")
+}
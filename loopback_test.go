@@ -0,0 +1,186 @@
+package router
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ixtendio/gofrebench/internal/hdr"
+	"github.com/valyala/fasthttp"
+	"io"
+	"net"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	loopbackConcurrency = flag.Int("loopback.concurrency", 0, "number of concurrent connections opened by the loopback benchmarks, 0 means 4 per GOMAXPROCS")
+	loopbackKeepAlive   = flag.Bool("loopback.keepalive", true, "reuse the connections between the requests of the loopback benchmarks")
+)
+
+// loopbackServer serves a framework router on a 127.0.0.1 listener
+type loopbackServer struct {
+	addr  string
+	close func()
+}
+
+// startLoopbackServer registers the routes into the named framework and starts an http.Server, or a fasthttp.Server
+// for the fasthttp frameworks, listening on a random port of the loopback interface
+func startLoopbackServer(name string, routes []*Route, handler Handler) (*loopbackServer, error) {
+	var serve func(ln net.Listener) error
+	var shutdown func()
+	if fastHTTPName, found := strings.CutPrefix(name, fastHTTPPrefix); found {
+		_, h, err := newFastHTTPRouter(fastHTTPName, routes, handler)
+		if err != nil {
+			return nil, err
+		}
+		srv := &fasthttp.Server{Handler: h}
+		serve = srv.Serve
+		shutdown = func() { srv.Shutdown() }
+	} else {
+		_, h, err := newRouter(name, routes, handler)
+		if err != nil {
+			return nil, err
+		}
+		srv := &http.Server{Handler: h}
+		serve = srv.Serve
+		shutdown = func() { srv.Close() }
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serve(ln)
+	}()
+	return &loopbackServer{
+		addr: ln.Addr().String(),
+		close: func() {
+			shutdown()
+			<-done
+		},
+	}, nil
+}
+
+// newLoopbackClient returns the load generator client, configured by the loopback flags
+func newLoopbackClient(concurrency int) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			MaxIdleConns:        concurrency,
+			MaxIdleConnsPerHost: concurrency,
+			DisableKeepAlives:   !*loopbackKeepAlive,
+			DisableCompression:  true,
+		},
+	}
+}
+
+func loopbackConcurrencyLevel() int {
+	if *loopbackConcurrency > 0 {
+		return *loopbackConcurrency
+	}
+	return 4 * runtime.GOMAXPROCS(0)
+}
+
+// benchmarkLoopback sends the scenario requests through the network stack: b.N requests are shared by concurrency
+// goroutines, each one sending its requests in order over its own connection. Besides ns/op, which is the inverse of
+// the throughput, the benchmark reports the requests per second and the latency percentiles, whatever the -latency flag.
+func benchmarkLoopback(b *testing.B, s *scenario) {
+	concurrency := loopbackConcurrencyLevel()
+	runFrameworks(b, s.set, func(b *testing.B, name string) {
+		s := s.forFramework(name)
+		requireConformance(b, name, s.set)
+		srv, err := startLoopbackServer(name, s.set.Routes, s.handler)
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer srv.close()
+		client := newLoopbackClient(concurrency)
+		defer client.CloseIdleConnections()
+
+		var (
+			next    int64
+			failed  atomic.Bool
+			wg      sync.WaitGroup
+			latency = &latencyRecorder{always: true}
+		)
+		// the state of the goroutines is allocated before the timer is started
+		histograms := make([]*hdr.Histogram, concurrency)
+		goroutineRequests := make([][]*http.Request, concurrency)
+		for g := range histograms {
+			histograms[g] = latency.histogram()
+			goroutineRequests[g] = loopbackRequests(srv.addr, s.requests)
+		}
+		b.ResetTimer()
+		start := time.Now()
+		for g := 0; g < concurrency; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				h, requests := histograms[g], goroutineRequests[g]
+				for i := g; atomic.AddInt64(&next, 1) <= int64(b.N) && !failed.Load(); i++ {
+					req := requests[i%len(requests)]
+					reqStart := time.Now()
+					status, err := sendLoopbackRequest(client, req)
+					h.Record(int64(time.Since(reqStart)))
+					if err != nil {
+						failed.Store(true)
+						b.Errorf("%s %s: %v", req.Method, req.URL.Path, err)
+					} else if !s.accept(status) {
+						failed.Store(true)
+						b.Errorf("got %d for %s %s", status, req.Method, req.URL.Path)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		elapsed := time.Since(start)
+		b.StopTimer()
+		for _, h := range histograms {
+			latency.merge(h)
+		}
+
+		b.ReportMetric(float64(b.N)/elapsed.Seconds(), "req/s")
+		latency.report(b)
+	})
+}
+
+// loopbackRequests copies the scenario requests for a goroutine, the requests are sent to the server address
+func loopbackRequests(addr string, requests []*http.Request) []*http.Request {
+	copies := make([]*http.Request, len(requests))
+	for i, r := range requests {
+		req, err := http.NewRequest(r.Method, "http://"+addr+r.URL.RequestURI(), nil)
+		if err != nil {
+			panic(err)
+		}
+		copies[i] = req
+	}
+	return copies
+}
+
+// sendLoopbackRequest sends the request and reads the whole response body, so the connection can be reused
+func sendLoopbackRequest(client *http.Client, req *http.Request) (int, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the response body: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+func Benchmark_Loopback_Static(b *testing.B) {
+	benchmarkLoopback(b, routesScenario(staticRouteSet, HandlerOK, true))
+}
+
+func Benchmark_Loopback_VarCapture(b *testing.B) {
+	benchmarkLoopback(b, routesScenario(varCaptureRouteSet, HandlerOK, true))
+}
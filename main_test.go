@@ -0,0 +1,35 @@
+package router
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+// TestMain prints the environment of a benchmark run as configuration lines of the Go benchmark format,
+// next to the goos, goarch and cpu lines printed by the testing package. The results parser (see internal/results)
+// attaches them to every result.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if *listFrameworks {
+		for _, name := range allFrameworks() {
+			fmt.Println(name)
+		}
+		os.Exit(0)
+	}
+	if err := checkSelectedFrameworks(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if f := flag.Lookup("test.bench"); f != nil && f.Value.String() != "" {
+		printConfig("go", runtime.Version())
+	}
+	os.Exit(m.Run())
+}
+
+// printConfig prints a configuration line, which applies to all the benchmark results printed after it
+func printConfig(key, value string) {
+	fmt.Printf("%s: %s\n", key, value)
+}
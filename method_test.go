@@ -0,0 +1,79 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// headScenario fires HEAD requests to the GET routes. Only the frameworks that automatically serve
+// a HEAD request with the GET handler are benchmarked.
+func headScenario(set *RouteSet, concurrent bool) *scenario {
+	s := &scenario{
+		set:     set,
+		handler: HandlerOK,
+		accept:  isStatusOK,
+		feature: "automatic HEAD",
+	}
+	if concurrent {
+		for _, r := range set.Routes {
+			if r.Method == http.MethodGet {
+				s.requests = append(s.requests, httptest.NewRequest(http.MethodHead, set.URL(r), nil))
+			}
+		}
+	} else {
+		s.requests = []*http.Request{httptest.NewRequest(http.MethodHead, "https://www.domain.com"+set.HotPath, nil)}
+	}
+	s.forSet = func(set *RouteSet) *scenario {
+		return headScenario(set, concurrent)
+	}
+	return s
+}
+
+// optionsScenario fires OPTIONS requests to the paths of the routes. Only the frameworks that automatically
+// answer an OPTIONS request with the allowed methods in the Allow header are benchmarked.
+func optionsScenario(set *RouteSet, concurrent bool) *scenario {
+	s := &scenario{
+		set:     set,
+		handler: HandlerOK,
+		accept:  isStatusOKOrNoContent,
+		feature: "automatic OPTIONS",
+		allow:   true,
+	}
+	if concurrent {
+		seen := map[string]bool{}
+		for _, r := range set.Routes {
+			url := set.URL(r)
+			if !seen[url] {
+				seen[url] = true
+				s.requests = append(s.requests, httptest.NewRequest(http.MethodOptions, url, nil))
+			}
+		}
+	} else {
+		s.requests = []*http.Request{httptest.NewRequest(http.MethodOptions, "https://www.domain.com"+set.HotPath, nil)}
+	}
+	s.forSet = func(set *RouteSet) *scenario {
+		return optionsScenario(set, concurrent)
+	}
+	return s
+}
+
+func isStatusOKOrNoContent(status int) bool {
+	return status == http.StatusOK || status == http.StatusNoContent
+}
+
+func Benchmark_Head(b *testing.B) {
+	benchmarkScenario(b, headScenario(varCaptureRouteSet, false))
+}
+
+func Benchmark_Head_Concurrent(b *testing.B) {
+	benchmarkScenarioConcurrent(b, headScenario(varCaptureRouteSet, true))
+}
+
+func Benchmark_Options(b *testing.B) {
+	benchmarkScenario(b, optionsScenario(varCaptureRouteSet, false))
+}
+
+func Benchmark_Options_Concurrent(b *testing.B) {
+	benchmarkScenarioConcurrent(b, optionsScenario(varCaptureRouteSet, true))
+}
@@ -0,0 +1,197 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// miss turns the path of a route into a request path that the route doesn't match anymore
+type miss struct {
+	name string
+	path func(route *Route, path string) string
+}
+
+var (
+	// nearMiss changes the last character of the last static segment, so the router walks almost the whole tree
+	nearMiss = miss{name: "near miss", path: func(route *Route, path string) string {
+		patternSegments := strings.Split(route.Path, "/")
+		pathSegments := strings.Split(path, "/")
+		for i := len(patternSegments) - 1; i > 0; i-- {
+			if segment := patternSegments[i]; segment != "" && paramName(segment) == "" {
+				last := segment[len(segment)-1]
+				if last == 'x' {
+					last = 'y'
+				} else {
+					last = 'x'
+				}
+				pathSegments[i] = segment[:len(segment)-1] + string(last)
+				return strings.Join(pathSegments, "/")
+			}
+		}
+		return ""
+	}}
+
+	// deepMiss appends segments to a matched path
+	deepMiss = miss{name: "deep miss", path: func(_ *Route, path string) string {
+		return path + "/deep/miss/below/the/route"
+	}}
+
+	// trailingSlashMiss appends a '/' to a path that doesn't end with one
+	trailingSlashMiss = miss{name: "trailing slash", path: func(_ *Route, path string) string {
+		if strings.HasSuffix(path, "/") {
+			return ""
+		}
+		return path + "/"
+	}}
+
+	// pathMisses are the misses that every router rejects, the trailing slash is accepted by the routers that
+	// consider it insignificant
+	pathMisses = []miss{nearMiss, deepMiss}
+
+	// wrongMethods are the methods tried, in order, to build a request that a path doesn't accept
+	wrongMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+)
+
+// missRequests returns, for every route, the requests built by the misses that no route of the set matches.
+// The requests keep the method of the route.
+func missRequests(set *RouteSet, routes []*Route, misses ...miss) []*http.Request {
+	var requests []*http.Request
+	seen := map[string]bool{}
+	for _, r := range routes {
+		u, err := url.Parse(set.URL(r))
+		if err != nil {
+			panic(err)
+		}
+		for _, m := range misses {
+			path := m.path(r, u.Path)
+			if path == "" || seen[path] || set.matches("", path) {
+				continue
+			}
+			seen[path] = true
+			requests = append(requests, httptest.NewRequest(r.Method, "https://www.domain.com"+path, nil))
+		}
+	}
+	return requests
+}
+
+// wrongMethodRequests returns, for every distinct route path, a request sent with a method that no route of the set
+// accepts for the path
+func wrongMethodRequests(set *RouteSet, routes []*Route) []*http.Request {
+	var requests []*http.Request
+	seen := map[string]bool{}
+	for _, r := range routes {
+		u := set.URL(r)
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+		path := strings.TrimPrefix(u, "https://www.domain.com")
+		for _, method := range wrongMethods {
+			if !set.matches(method, path) {
+				requests = append(requests, httptest.NewRequest(method, u, nil))
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// matches reports whether a route of the set matches the request path and, if not empty, the method
+func (s *RouteSet) matches(method, path string) bool {
+	for _, r := range s.Routes {
+		if (method == "" || r.Method == method) && r.Matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// hotRoute returns the GET route that matches the hot path of the set
+func (s *RouteSet) hotRoute() *Route {
+	for _, r := range s.Routes {
+		if r.Method == http.MethodGet && r.Matches(s.HotPath) {
+			return r
+		}
+	}
+	panic(fmt.Sprintf("no GET route of %s matches the hot path %s", s.Name, s.HotPath))
+}
+
+// notFoundScenario fires requests to paths that no route matches and expects a 404 response. The single-threaded
+// benchmark applies the misses to the hot route, the concurrent one applies them to every route. A framework that
+// serves one of the requests with a route doesn't have the feature and is skipped.
+func notFoundScenario(set *RouteSet, feature string, concurrent bool, misses ...miss) *scenario {
+	s := &scenario{
+		set:     set,
+		handler: HandlerOK,
+		accept:  isStatusNotFound,
+		feature: feature,
+	}
+	if concurrent {
+		s.requests = missRequests(set, set.Routes, misses...)
+	} else {
+		s.requests = missRequests(set, []*Route{set.hotRoute()}, misses...)
+	}
+	if len(s.requests) == 0 {
+		panic(fmt.Sprintf("no %s request for the %s routes", misses[0].name, set.Name))
+	}
+	s.forSet = func(set *RouteSet) *scenario {
+		return notFoundScenario(set, feature, concurrent, misses...)
+	}
+	return s
+}
+
+// methodNotAllowedScenario fires requests to the route paths with a method that the path doesn't accept and expects
+// a 405 response. The frameworks that don't check the methods allowed for the path, and answer 404, are skipped.
+func methodNotAllowedScenario(set *RouteSet, concurrent bool) *scenario {
+	s := &scenario{
+		set:     set,
+		handler: HandlerOK,
+		accept:  isStatusMethodNotAllowed,
+		feature: "405 Method Not Allowed",
+	}
+	if concurrent {
+		s.requests = wrongMethodRequests(set, set.Routes)
+	} else {
+		s.requests = wrongMethodRequests(set, []*Route{set.hotRoute()})
+	}
+	s.forSet = func(set *RouteSet) *scenario {
+		return methodNotAllowedScenario(set, concurrent)
+	}
+	return s
+}
+
+func isStatusNotFound(status int) bool {
+	return status == http.StatusNotFound
+}
+
+func isStatusMethodNotAllowed(status int) bool {
+	return status == http.StatusMethodNotAllowed
+}
+
+func Benchmark_NotFound_NearMiss(b *testing.B) {
+	benchmarkScenario(b, notFoundScenario(varCaptureRouteSet, "strict path matching", false, nearMiss))
+}
+
+func Benchmark_NotFound_DeepMiss(b *testing.B) {
+	benchmarkScenario(b, notFoundScenario(varCaptureRouteSet, "strict path matching", false, deepMiss))
+}
+
+func Benchmark_NotFound_TrailingSlash(b *testing.B) {
+	benchmarkScenario(b, notFoundScenario(varCaptureRouteSet, "strict trailing slash", false, trailingSlashMiss))
+}
+
+func Benchmark_NotFound_Concurrent(b *testing.B) {
+	benchmarkScenarioConcurrent(b, notFoundScenario(varCaptureRouteSet, "strict path matching", true, pathMisses...))
+}
+
+func Benchmark_MethodNotAllowed(b *testing.B) {
+	benchmarkScenario(b, methodNotAllowedScenario(varCaptureRouteSet, false))
+}
+
+func Benchmark_MethodNotAllowed_Concurrent(b *testing.B) {
+	benchmarkScenarioConcurrent(b, methodNotAllowedScenario(varCaptureRouteSet, true))
+}
@@ -0,0 +1,122 @@
+package router
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ixtendio/gofrebench/internal/openapi"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var openAPISpec = flag.String("openapi", "", "OpenAPI 3 specification, in YAML or JSON, whose operations are benchmarked by the OpenAPI benchmarks")
+
+// openAPISpecSet is the route set loaded from a specification
+type openAPISpecSet struct {
+	set *RouteSet
+	// skipped describes the operations of the specification that cannot be benchmarked
+	skipped []string
+}
+
+var (
+	openAPIMu   sync.Mutex
+	openAPISets = map[string]*openAPISpecSet{}
+)
+
+// openAPIRouteSet returns the route set of the operations of the specification, loaded on first use, and the
+// operations left out. The hot path is the first GET operation.
+func openAPIRouteSet(path string) (*RouteSet, []string, error) {
+	openAPIMu.Lock()
+	defer openAPIMu.Unlock()
+	if loaded, found := openAPISets[path]; found {
+		return loaded.set, loaded.skipped, nil
+	}
+	spec, err := openapi.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	set := &RouteSet{
+		Name:         "openapi-" + strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		SampleValues: map[*Route]map[string]string{},
+	}
+	for _, o := range spec.Operations {
+		r := &Route{Method: o.Method, Path: o.Path}
+		set.SampleValues[r] = o.Values
+		set.Routes = append(set.Routes, r)
+		if set.HotPath == "" && o.Method == "GET" {
+			set.HotPath = strings.TrimPrefix(set.URL(r), "https://www.domain.com")
+		}
+	}
+	openAPISets[path] = &openAPISpecSet{set: set, skipped: spec.Skipped}
+	return set, spec.Skipped, nil
+}
+
+// skippedReported makes the operations left out of the -openapi route set reported once per run
+var skippedReported sync.Once
+
+// reportSkippedOperations prints the operations of the -openapi specification left out of the route set, on lines
+// ignored by the results parser. The log of a benchmark with sub-benchmarks is only printed with -test.v.
+func reportSkippedOperations(skipped []string) {
+	skippedReported.Do(func() {
+		for _, operation := range skipped {
+			fmt.Printf("OpenAPI operation not benchmarked: %s\n", operation)
+		}
+	})
+}
+
+// requireOpenAPIRouteSet returns the route set of the -openapi specification, the benchmark is skipped without it
+func requireOpenAPIRouteSet(b *testing.B, needHotPath bool) *RouteSet {
+	if *openAPISpec == "" {
+		b.Skip("no OpenAPI specification, run with -openapi spec.yaml")
+	}
+	set, skipped, err := openAPIRouteSet(*openAPISpec)
+	if err != nil {
+		b.Fatal(err)
+	}
+	reportSkippedOperations(skipped)
+	if needHotPath && set.HotPath == "" {
+		b.Skipf("%s has no GET operation", *openAPISpec)
+	}
+	return set
+}
+
+func Benchmark_OpenAPI(b *testing.B) {
+	benchmarkRoutes(b, requireOpenAPIRouteSet(b, true), HandlerOK)
+}
+
+func Benchmark_OpenAPI_Concurrent(b *testing.B) {
+	benchmarkRoutesConcurrent(b, requireOpenAPIRouteSet(b, false), HandlerOK)
+}
+
+func TestOpenAPIRouteSet(t *testing.T) {
+	set, skipped, err := openAPIRouteSet("internal/openapi/testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Name != "openapi-petstore" || set.HotPath != "/v1/pets" || len(set.Routes) != 8 {
+		t.Fatalf("openAPIRouteSet() got %s with hot path %s and %d routes", set.Name, set.HotPath, len(set.Routes))
+	}
+	if len(skipped) != 1 || !strings.Contains(skipped[0], "/files/{name}.{extension}") {
+		t.Errorf("openAPIRouteSet() got skipped: %q, want the /files/{name}.{extension} operation", skipped)
+	}
+	for _, r := range set.Routes {
+		if r.Path == "/v1/stores/{storeId}/orders/{status}" {
+			if got := set.URL(r); got != "https://www.domain.com/v1/stores/store-42/orders/placed" {
+				t.Errorf("URL() got %s, want the example values of the specification", got)
+			}
+		}
+	}
+
+	for _, name := range benchmarkedFrameworks() {
+		t.Run(name, func(t *testing.T) {
+			result := checkConformance(name, set)
+			if result.err != nil {
+				t.Skipf("%s does not support the %s routes: %v", name, set.Name, result.err)
+			}
+			for _, f := range result.failures {
+				t.Error(f)
+			}
+		})
+	}
+}
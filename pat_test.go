@@ -0,0 +1,170 @@
+package router
+
+import (
+	"github.com/bmizerany/pat"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+func init() {
+	registerFramework("pat", newPatAdapter)
+}
+
+// patAdapter benchmarks the pattern matching router from bmizerany/pat.
+//
+// pat tries the patterns in registration order and the first match wins, while a pattern ending with '/'
+// matches the whole subtree. The routes are therefore registered in Build, ordered from the most specific
+// to the least specific one, which is what a pat user has to do by hand.
+type patAdapter struct {
+	m           *pat.PatternServeMux
+	routes      []patRoute
+	middlewares []Middleware
+}
+
+type patRoute struct {
+	method  string
+	path    string
+	handler http.HandlerFunc
+}
+
+func newPatAdapter() FrameworkAdapter {
+	return &patAdapter{m: pat.New()}
+}
+
+func (a *patAdapter) Name() string {
+	return "pat"
+}
+
+func (a *patAdapter) Version() string {
+	return moduleVersion("github.com/bmizerany/pat")
+}
+
+// patSyntax converts the {name} path variables into the :name syntax.
+// The pat variable names are alphanumeric only, so the other characters are removed (see patParam).
+// pat can't capture the rest of the path, so the *name catch-all variable is converted into a prefix pattern.
+// pat has no constraints, the handler validates the constrained variables.
+var patSyntax = pathSyntax{
+	param: patParam,
+	catchAll: func(string) string {
+		return ""
+	},
+}
+
+func (a *patAdapter) TranslatePath(path string) string {
+	return routePattern(path).format(patSyntax)
+}
+
+// Use adds a middleware wrapping the router, pat has no middlewares
+func (a *patAdapter) Use(m Middleware) {
+	a.middlewares = append(a.middlewares, m)
+}
+
+func (a *patAdapter) Register(route *Route, path string, h Handler) error {
+	handler := patHandler(route, path, h)
+	if constraints := routePattern(route.Path).constraints(); constraints != nil {
+		next := handler
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if !constraints.valid(func(name string) string { return query.Get(patParam(name)) }) {
+				http.NotFound(w, r)
+				return
+			}
+			next(w, r)
+		}
+	}
+	a.routes = append(a.routes, patRoute{
+		method:  route.Method,
+		path:    path,
+		handler: handler,
+	})
+	return nil
+}
+
+func (a *patAdapter) Build() http.Handler {
+	sort.SliceStable(a.routes, func(i, j int) bool {
+		return patSpecificity(a.routes[i].path).less(patSpecificity(a.routes[j].path))
+	})
+	for _, r := range a.routes {
+		if r.method == http.MethodGet {
+			// like a pat application does, which also serves the HEAD requests
+			a.m.Get(r.path, r.handler)
+		} else {
+			a.m.Add(r.method, r.path, r.handler)
+		}
+	}
+	// pat prepends the path variables to the query string of the request, which would grow on every
+	// iteration because the benchmarks reuse the same requests
+	return wrapHTTPMiddlewares(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQuery := r.URL.RawQuery
+		a.m.ServeHTTP(w, r)
+		r.URL.RawQuery = rawQuery
+	}), a.middlewares)
+}
+
+// patParam returns the pat syntax of a path variable, dropping the characters pat does not accept in a name
+func patParam(name string) string {
+	var sb strings.Builder
+	sb.WriteByte(':')
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+type specificity struct {
+	prefix     bool
+	params     int
+	pathLength int
+}
+
+func patSpecificity(path string) specificity {
+	return specificity{
+		prefix:     len(path) > 1 && strings.HasSuffix(path, "/"),
+		params:     strings.Count(path, ":"),
+		pathLength: len(path),
+	}
+}
+
+// less reports whether s must be tried before o: exact patterns before prefixes,
+// fewer path variables first and, for the same number of variables, the longest pattern first
+func (s specificity) less(o specificity) bool {
+	if s.prefix != o.prefix {
+		return !s.prefix
+	}
+	if s.params != o.params {
+		return s.params < o.params
+	}
+	return s.pathLength > o.pathLength
+}
+
+func patHandler(route *Route, pattern string, h Handler) http.HandlerFunc {
+	catchAll := route.CatchAll()
+	pathVar := func(r *http.Request) func(name string) string {
+		query := r.URL.Query()
+		return func(name string) string {
+			if name == catchAll {
+				return pat.Tail(pattern, r.URL.Path)
+			}
+			return query.Get(patParam(name))
+		}
+	}
+	switch h {
+	case HandlerRouteInfo:
+		return func(w http.ResponseWriter, r *http.Request) {
+			writePlainText(w, routeInfo(route, pathVar(r)))
+		}
+	case HandlerParamRead:
+		params := route.Params()
+		return func(w http.ResponseWriter, r *http.Request) {
+			writePlainText(w, joinParams(params, pathVar(r)))
+		}
+	default:
+		return func(w http.ResponseWriter, r *http.Request) {
+			writePlainText(w, "ok")
+		}
+	}
+}
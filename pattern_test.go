@@ -0,0 +1,273 @@
+package router
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// segmentKind is the kind of a segment of a route path
+type segmentKind uint8
+
+const (
+	// segmentLiteral is a static segment, matched as it is
+	segmentLiteral segmentKind = iota
+	// segmentParam is a path variable declared as {name}, which matches any non-empty segment
+	segmentParam
+	// segmentConstrained is a path variable declared as {name:constraint}, which matches the segments satisfying the
+	// constraint: a regular expression like {id:[0-9]+} or a type like {id:int} (see paramTypes)
+	segmentConstrained
+	// segmentCatchAll is the catch-all variable declared as *name, which matches the rest of the path
+	segmentCatchAll
+)
+
+// paramTypes are the types of the typed path variables with the regular expression of their values
+var paramTypes = map[string]string{
+	"int":   `[0-9]+`,
+	"alpha": `[a-zA-Z]+`,
+	"uuid":  `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+}
+
+// patternSegment is a node of the parsed route path
+type patternSegment struct {
+	Kind segmentKind
+	// Value is the text of a literal segment or the name of a path variable
+	Value string
+	// Type is the type of a typed path variable, empty for a regular expression constraint
+	Type string
+	// Pattern is the regular expression matched by a constrained path variable, the one of its type if it is typed
+	Pattern string
+	re      *regexp.Regexp
+}
+
+// matches reports whether the value of a path segment satisfies the constraint of the segment
+func (s *patternSegment) matches(value string) bool {
+	switch s.Kind {
+	case segmentLiteral:
+		return value == s.Value
+	case segmentConstrained:
+		return s.re.MatchString(value)
+	}
+	return value != ""
+}
+
+// anchoredPattern returns the regular expression of a constrained path variable anchored at both ends, for the
+// frameworks that match it against the segment without anchoring it
+func (s *patternSegment) anchoredPattern() string {
+	return "^(?:" + s.Pattern + ")$"
+}
+
+// pathPattern is a parsed route path, one segment per '/' separated part of the path
+type pathPattern []patternSegment
+
+// parsePattern parses a route path declared with the {name}, {name:constraint} and *name syntax
+func parsePattern(path string) (pathPattern, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("the path %s doesn't start with /", path)
+	}
+	parts := strings.Split(path[1:], "/")
+	pattern := make(pathPattern, len(parts))
+	for i, part := range parts {
+		s := &pattern[i]
+		switch {
+		case strings.HasPrefix(part, "*"):
+			if i != len(parts)-1 || len(part) == 1 {
+				return nil, fmt.Errorf("the catch-all variable of %s must be named and be the last segment", path)
+			}
+			s.Kind, s.Value = segmentCatchAll, part[1:]
+		case strings.HasPrefix(part, "{"):
+			if !strings.HasSuffix(part, "}") {
+				return nil, fmt.Errorf("the segment %s of %s is not a single path variable", part, path)
+			}
+			name, constraint, constrained := strings.Cut(part[1:len(part)-1], ":")
+			if name == "" {
+				return nil, fmt.Errorf("the segment %s of %s has no variable name", part, path)
+			}
+			s.Kind, s.Value = segmentParam, name
+			if !constrained {
+				continue
+			}
+			if constraint == "" {
+				return nil, fmt.Errorf("the segment %s of %s has an empty constraint", part, path)
+			}
+			s.Kind, s.Pattern = segmentConstrained, constraint
+			if p, found := paramTypes[constraint]; found {
+				s.Type, s.Pattern = constraint, p
+			}
+			re, err := regexp.Compile(s.anchoredPattern())
+			if err != nil {
+				return nil, fmt.Errorf("the constraint of the segment %s of %s: %w", part, path, err)
+			}
+			s.re = re
+		default:
+			s.Kind, s.Value = segmentLiteral, part
+		}
+	}
+	return pattern, nil
+}
+
+var routePatterns sync.Map
+
+// routePattern returns the parsed path of a route. The route paths are checked by parsePattern when they are
+// registered, it panics for an invalid path.
+func routePattern(path string) pathPattern {
+	if p, found := routePatterns.Load(path); found {
+		return p.(pathPattern)
+	}
+	p, err := parsePattern(path)
+	if err != nil {
+		panic(err)
+	}
+	routePatterns.Store(path, p)
+	return p
+}
+
+// pathSyntax describes how a framework declares the path variables
+type pathSyntax struct {
+	param func(name string) string
+	// constrained formats a constrained path variable, nil if the framework cannot declare constraints: the variable
+	// is declared like an unconstrained one and the handler validates it (see paramConstraints)
+	constrained func(s *patternSegment) string
+	catchAll    func(name string) string
+}
+
+// format emits the path in the framework syntax
+func (p pathPattern) format(syntax pathSyntax) string {
+	var sb strings.Builder
+	for i := range p {
+		s := &p[i]
+		sb.WriteByte('/')
+		switch {
+		case s.Kind == segmentLiteral:
+			sb.WriteString(s.Value)
+		case s.Kind == segmentCatchAll:
+			sb.WriteString(syntax.catchAll(s.Value))
+		case s.Kind == segmentConstrained && syntax.constrained != nil:
+			sb.WriteString(syntax.constrained(s))
+		default:
+			sb.WriteString(syntax.param(s.Value))
+		}
+	}
+	return sb.String()
+}
+
+// constraints returns the constrained path variables of the path
+func (p pathPattern) constraints() paramConstraints {
+	var constraints paramConstraints
+	for i := range p {
+		if p[i].Kind == segmentConstrained {
+			constraints = append(constraints, &p[i])
+		}
+	}
+	return constraints
+}
+
+// paramConstraints are the constrained path variables of a route, validated by the handlers of the frameworks that
+// cannot declare constraints, like an application using such a framework does
+type paramConstraints []*patternSegment
+
+// valid reports whether the values of the path variables, read with the framework accessor, satisfy the constraints
+func (c paramConstraints) valid(pathVar func(name string) string) bool {
+	for _, s := range c {
+		if !s.re.MatchString(pathVar(s.Value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// colonSyntax converts the {name} path variables into the :name syntax, the *name catch-all variable is kept as it is.
+// It is the syntax of the frameworks derived from httprouter, which have no constraints: the handler validates the
+// constrained variables.
+var colonSyntax = pathSyntax{
+	param: colonParam,
+	catchAll: func(name string) string {
+		return "*" + name
+	},
+}
+
+// bracesParam declares a path variable with the {name} syntax
+func bracesParam(name string) string {
+	return "{" + name + "}"
+}
+
+// colonParam declares a path variable with the :name syntax
+func colonParam(name string) string {
+	return ":" + name
+}
+
+func TestParsePattern(t *testing.T) {
+	got, err := parsePattern("/users/{id:int}/products/{sku:[A-Z]{3}-[0-9]{4}}/{name}/*path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []patternSegment{
+		{Kind: segmentLiteral, Value: "users"},
+		{Kind: segmentConstrained, Value: "id", Type: "int", Pattern: "[0-9]+"},
+		{Kind: segmentLiteral, Value: "products"},
+		{Kind: segmentConstrained, Value: "sku", Pattern: "[A-Z]{3}-[0-9]{4}"},
+		{Kind: segmentParam, Value: "name"},
+		{Kind: segmentCatchAll, Value: "path"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsePattern() got %d segments, want: %d", len(got), len(want))
+	}
+	for i := range want {
+		got[i].re = nil
+		if got[i] != want[i] {
+			t.Errorf("parsePattern() got segment %d: %+v, want: %+v", i, got[i], want[i])
+		}
+	}
+
+	for _, path := range []string{"users", "/a/*path/b", "/a/*", "/a/{}", "/a/{id", "/a/{id:}", "/a/{id:[0-9}"} {
+		if _, err := parsePattern(path); err == nil {
+			t.Errorf("parsePattern(%q) expected an error", path)
+		}
+	}
+}
+
+func TestRoute_Matches_constraints(t *testing.T) {
+	route := &Route{Method: "GET", Path: "/archive/{year:[0-9]{4}}/{month:0[1-9]|1[0-2]}/{slug}"}
+	tests := map[string]bool{
+		"/archive/2024/03/gophers":  true,
+		"/archive/2024/12/gophers":  true,
+		"/archive/2024/13/gophers":  false,
+		"/archive/2024/012/gophers": false,
+		"/archive/24/03/gophers":    false,
+		"/archive/2024/03/":         false,
+	}
+	for path, want := range tests {
+		if got := route.Matches(path); got != want {
+			t.Errorf("Matches(%s) got: %v, want: %v", path, got, want)
+		}
+	}
+}
+
+func TestTranslatePath(t *testing.T) {
+	path := "/users/{id:int}/tags/{tag:[a-z]+}/files/*path"
+	tests := map[string]string{
+		"chi":                     "/users/{id:^(?:[0-9]+)$}/tags/{tag:^(?:[a-z]+)$}/files/*",
+		"echo":                    "/users/:id/tags/:tag/files/*",
+		"gin":                     "/users/:id/tags/:tag/files/*path",
+		"gofre":                   "/users/{id:^(?:[0-9]+)$}/tags/{tag:^(?:[a-z]+)$}/files/**",
+		"gorilla":                 "/users/{id:[0-9]+}/tags/{tag:[a-z]+}/files/{path:.*}",
+		"httprouter":              "/users/:id/tags/:tag/files/*path",
+		"pat":                     "/users/:id/tags/:tag/files/",
+		"servemux":                "/users/{id}/tags/{tag}/files/{path...}",
+		"fasthttp/fasthttprouter": "/users/{id:[0-9]+}/tags/{tag:[a-z]+}/files/{path:*}",
+		"fasthttp/fiber":          "/users/:id<int>/tags/:tag<regex(^(?:[a-z]+)$)>/files/*",
+	}
+	for name, want := range tests {
+		var got string
+		if fastHTTPName, found := strings.CutPrefix(name, fastHTTPPrefix); found {
+			got = fastHTTPFrameworks[fastHTTPName]().TranslatePath(path)
+		} else {
+			got = frameworks[name]().TranslatePath(path)
+		}
+		if got != want {
+			t.Errorf("%s TranslatePath() got: %s, want: %s", name, got, want)
+		}
+	}
+}
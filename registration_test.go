@@ -0,0 +1,93 @@
+package router
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// retainedHeapRuns is the number of routers built to measure the retained heap, the smallest measure is reported
+const retainedHeapRuns = 3
+
+// frameworkPaths parses the route paths and translates them into the syntax of the named framework, so the
+// benchmarks don't measure the harness. The name of a fasthttp framework must have the fasthttp/ prefix.
+func frameworkPaths(name string, routes []*Route) ([]string, error) {
+	if fastHTTPName, found := strings.CutPrefix(name, fastHTTPPrefix); found {
+		return translatePaths(routes, fastHTTPFrameworks[fastHTTPName]().TranslatePath)
+	}
+	return translatePaths(routes, frameworks[name]().TranslatePath)
+}
+
+// buildRouter registers the routes, with the paths returned by frameworkPaths, into the named framework and builds the
+// router, without creating any client. The name of a fasthttp framework must have the fasthttp/ prefix.
+func buildRouter(name string, routes []*Route, paths []string, handler Handler) (any, error) {
+	if fastHTTPName, found := strings.CutPrefix(name, fastHTTPPrefix); found {
+		_, h, err := registerFastHTTPRouter(fastHTTPName, routes, paths, handler)
+		return h, err
+	}
+	_, h, err := registerRouter(name, routes, paths, handler)
+	return h, err
+}
+
+// retainedHeap returns the heap bytes still referenced by a router after a forced garbage collection
+func retainedHeap(name string, routes []*Route, paths []string) (uint64, error) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	router, err := buildRouter(name, routes, paths, HandlerOK)
+	if err != nil {
+		return 0, err
+	}
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(router)
+	if after.HeapAlloc < before.HeapAlloc {
+		return 0, nil
+	}
+	return after.HeapAlloc - before.HeapAlloc, nil
+}
+
+// benchmarkRegistration measures the construction of the router of a route set: ns/op is the time to register all the
+// routes and build the router, B/op and allocs/op are allocated during the construction, and retained-B is the heap
+// retained by the built router after a forced garbage collection, what a service keeps paying for its whole life.
+// The route paths are translated into the framework syntax before the timer is started.
+func benchmarkRegistration(b *testing.B, set *RouteSet) {
+	runFrameworks(b, set, func(b *testing.B, name string) {
+		set := frameworkRouteSet(name, set)
+		paths, err := frameworkPaths(name, set.Routes)
+		if err != nil {
+			b.Fatal(err)
+		}
+		retained := ^uint64(0)
+		for i := 0; i < retainedHeapRuns; i++ {
+			heap, err := retainedHeap(name, set.Routes, paths)
+			if err != nil {
+				var regErr *RegistrationError
+				if errors.As(err, &regErr) {
+					b.Skipf("%s does not support the %s routes: %v", name, set.Name, err)
+				}
+				b.Fatal(err)
+			}
+			retained = min(retained, heap)
+		}
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := buildRouter(name, set.Routes, paths, HandlerOK); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StopTimer()
+		b.ReportMetric(float64(retained), "retained-B")
+	})
+}
+
+func Benchmark_Registration_Static(b *testing.B) {
+	benchmarkRegistration(b, staticRouteSet)
+}
+
+func Benchmark_Registration_VarCapture(b *testing.B) {
+	benchmarkRegistration(b, varCaptureRouteSet)
+}
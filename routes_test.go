@@ -0,0 +1,793 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+)
+
+type (
+	Route struct {
+		Method string
+		Path   string
+	}
+
+	// RouteSet is a named route table that can be registered into every framework
+	RouteSet struct {
+		Name   string
+		Routes []*Route
+		// HotPath is the request path used by the single-threaded benchmarks
+		HotPath string
+		// CheckedRoutes are the routes requested by the conformance check, all the routes if empty
+		CheckedRoutes []*Route
+		// SampleValues are the values of the path variables of some routes, like the examples of an OpenAPI
+		// specification, which take precedence over the common sample values
+		SampleValues map[*Route]map[string]string
+		// Compatible, if not nil, is the set without the routes that some frameworks reject. The frameworks that cannot
+		// register the set are benchmarked with it instead of being skipped, their results carry its name.
+		Compatible *RouteSet
+	}
+)
+
+// Params returns the names of the path variables declared by the route, in declaration order.
+// The catch-all variable (see CatchAll), if declared, is the last one.
+func (r *Route) Params() []string {
+	var params []string
+	for _, segment := range strings.Split(r.Path, "/") {
+		if name := paramName(segment); name != "" {
+			params = append(params, name)
+		}
+	}
+	return params
+}
+
+// NativeParams returns the same names as Params, except the catch-all variable which is renamed to catchAll.
+// It is used by the frameworks that don't name the catch-all variable, like Echo with "*".
+func (r *Route) NativeParams(catchAll string) []string {
+	params := r.Params()
+	if r.CatchAll() != "" {
+		params[len(params)-1] = catchAll
+	}
+	return params
+}
+
+// CatchAll returns the name of the catch-all variable, declared with *name as the last path segment,
+// or an empty string if the route doesn't have one. The catch-all variable captures the rest of the path.
+func (r *Route) CatchAll() string {
+	if i := strings.LastIndexByte(r.Path, '/'); i >= 0 && strings.HasPrefix(r.Path[i+1:], "*") {
+		return r.Path[i+2:]
+	}
+	return ""
+}
+
+// SampleValue returns the value of the named path variable in the URL returned by URL
+func (r *Route) SampleValue(name string) string {
+	if name == r.CatchAll() {
+		return sampleCatchAllValue(name)
+	}
+	return sampleParamValue(name)
+}
+
+// URL returns a concrete request URL for the route, where every path variable is replaced by its sample value
+func (r *Route) URL() string {
+	return r.URLWith(r.SampleValue)
+}
+
+// URLWith returns a concrete request URL for the route, where every path variable is replaced by the given value
+func (r *Route) URLWith(value func(name string) string) string {
+	segments := strings.Split(r.Path, "/")
+	for i, segment := range segments {
+		if name := paramName(segment); name != "" {
+			segments[i] = value(name)
+		}
+	}
+	return "https://www.domain.com" + strings.Join(segments, "/")
+}
+
+// Matches reports whether the request path is matched by the route path, ignoring the method.
+// A {name} variable matches one non-empty segment, a {name:constraint} variable one segment satisfying the constraint
+// and a *name catch-all variable matches the rest of the path.
+func (r *Route) Matches(path string) bool {
+	if !strings.HasPrefix(path, "/") {
+		return false
+	}
+	pattern := routePattern(r.Path)
+	pathSegments := strings.Split(path[1:], "/")
+	for i := range pattern {
+		if pattern[i].Kind == segmentCatchAll {
+			return len(pathSegments) > i && pathSegments[i] != ""
+		}
+		if i >= len(pathSegments) || !pattern[i].matches(pathSegments[i]) {
+			return false
+		}
+	}
+	return len(pattern) == len(pathSegments)
+}
+
+// paramName returns the name of the path variable declared by the segment, {name}, {name:constraint} or *name,
+// or an empty string
+func paramName(segment string) string {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		name, _, _ := strings.Cut(segment[1:len(segment)-1], ":")
+		return name
+	}
+	if strings.HasPrefix(segment, "*") {
+		return segment[1:]
+	}
+	return ""
+}
+
+// withoutRoutes returns the routes, except the excluded ones
+func withoutRoutes(routes []*Route, excluded ...Route) []*Route {
+	var kept []*Route
+	for _, r := range routes {
+		if !slices.Contains(excluded, *r) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// SampleValue returns the value of the named path variable of a route of the set in the URL returned by URL
+func (s *RouteSet) SampleValue(r *Route, name string) string {
+	if v, found := s.SampleValues[r][name]; found {
+		return v
+	}
+	return r.SampleValue(name)
+}
+
+// URL returns a concrete request URL for a route of the set, where every path variable is replaced by its sample value
+func (s *RouteSet) URL(r *Route) string {
+	return r.URLWith(func(name string) string {
+		return s.SampleValue(r, name)
+	})
+}
+
+// routeRequests returns one concrete request per route of the set, using the route method and its sample URL
+func routeRequests(set *RouteSet, routes []*Route) []*http.Request {
+	requests := make([]*http.Request, len(routes))
+	for i, r := range routes {
+		requests[i] = httptest.NewRequest(r.Method, set.URL(r), nil)
+	}
+	return requests
+}
+
+// sampleParamValue returns a realistic value for a path variable.
+// The values are distinct, so a framework that swaps two variables is detected by the conformance check.
+func sampleParamValue(name string) string {
+	if v, found := sampleParamValues[name]; found {
+		return v
+	}
+	return name + "-value"
+}
+
+// sampleCatchAllValue returns a realistic value, spanning several path segments, for a catch-all variable
+func sampleCatchAllValue(name string) string {
+	if v, found := sampleCatchAllValues[name]; found {
+		return v
+	}
+	return name + "/multiple/segments"
+}
+
+var (
+	staticRouteSet = &RouteSet{
+		Name:    "static",
+		Routes:  staticRoutes,
+		HotPath: "/gopher/pencil/gopherhelmet.jpg",
+	}
+
+	varCaptureRouteSet = &RouteSet{
+		Name:       "varcapture",
+		Routes:     varCaptureRoutes,
+		HotPath:    "/repos/owner/repo/commits/sha",
+		Compatible: varCaptureCompatibleRouteSet,
+	}
+
+	// varCaptureCompatibleRouteSet is the GitHub API without the routes whose static segment conflicts with a path
+	// variable at the same position, which httprouter rejects
+	varCaptureCompatibleRouteSet = &RouteSet{
+		Name: "varcapture-compatible",
+		Routes: withoutRoutes(varCaptureRoutes,
+			Route{"GET", "/gists/public"},
+			Route{"GET", "/gists/starred"},
+			Route{"PATCH", "/repos/{owner}/{repo}/issues/comments/{id}"},
+			Route{"PATCH", "/repos/{owner}/{repo}/pulls/comments/{number}"},
+		),
+		HotPath: "/repos/owner/repo/commits/sha",
+	}
+
+	wildcardRouteSet = &RouteSet{
+		Name:    "wildcard",
+		Routes:  wildcardRoutes,
+		HotPath: "/repos/ixtendio/gofre/contents/router/path/matcher.go",
+	}
+
+	parseRouteSet = &RouteSet{
+		Name:    "parse",
+		Routes:  parseRoutes,
+		HotPath: "/1/classes/GameScore/Ed1nuqPvcm",
+	}
+
+	gplusRouteSet = &RouteSet{
+		Name:    "gplus",
+		Routes:  gplusRoutes,
+		HotPath: "/people/118051310819094153327/activities/public",
+	}
+
+	constrainedRouteSet = &RouteSet{
+		Name:    "constrained",
+		Routes:  constrainedRoutes,
+		HotPath: "/users/1296269/orders/3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	}
+
+	routeSets = []*RouteSet{staticRouteSet, varCaptureRouteSet, varCaptureCompatibleRouteSet, wildcardRouteSet, parseRouteSet, gplusRouteSet, constrainedRouteSet}
+
+	sampleCatchAllValues = map[string]string{
+		"filepath": "css/bootstrap/bootstrap.min.css",
+		"path":     "router/path/matcher.go",
+		"ref":      "heads/feature/catch-all",
+	}
+
+	sampleParamValues = map[string]string{
+		"access_token":   "e72e16c7e42f292c6912e7710c838347ae178b4a",
+		"activityId":     "z12gtjhq3qn2xxl2o224exwiqruvtda0i",
+		"archive_format": "tarball",
+		"assignee":       "hubot",
+		"branch":         "main",
+		"className":      "GameScore",
+		"client_id":      "9d3b3c2f1a5e8d7c6b4a",
+		"collection":     "public",
+		"commentId":      "z12mtnmxszmbitwk004cgn2yxsydlfq2p04",
+		"email":          "octocat@github.com",
+		"eventName":      "AppOpened",
+		"fileName":       "pic.jpg",
+		"id":             "1296269",
+		"keyword":        "router",
+		"month":          "03",
+		"name":           "bug",
+		"number":         "1347",
+		"objectId":       "Ed1nuqPvcm",
+		"orderId":        "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+		"org":            "golang",
+		"owner":          "ixtendio",
+		"page":           "2",
+		"ref":            "v1.1.0",
+		"repo":           "gofre",
+		"repository":     "gofrebench",
+		"sha":            "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		"sku":            "GPH-1024",
+		"slug":           "path-constraints",
+		"state":          "open",
+		"tag":            "gophers",
+		"target_user":    "defunkt",
+		"user":           "octocat",
+		"userId":         "118051310819094153327",
+		"year":           "2024",
+	}
+
+	staticRoutes = []*Route{
+		{"GET", "/"},
+		{"GET", "/cmd.html"},
+		{"GET", "/code.html"},
+		{"GET", "/contrib.html"},
+		{"GET", "/contribute.html"},
+		{"GET", "/debugging_with_gdb.html"},
+		{"GET", "/docs.html"},
+		{"GET", "/effective_go.html"},
+		{"GET", "/files.log"},
+		{"GET", "/gccgo_contribute.html"},
+		{"GET", "/gccgo_install.html"},
+		{"GET", "/go-logo-black.png"},
+		{"GET", "/go-logo-blue.png"},
+		{"GET", "/go-logo-white.png"},
+		{"GET", "/go1.1.html"},
+		{"GET", "/go1.2.html"},
+		{"GET", "/go1.html"},
+		{"GET", "/go1compat.html"},
+		{"GET", "/go_faq.html"},
+		{"GET", "/go_mem.html"},
+		{"GET", "/go_spec.html"},
+		{"GET", "/help.html"},
+		{"GET", "/ie.css"},
+		{"GET", "/install-source.html"},
+		{"GET", "/install.html"},
+		{"GET", "/logo-153x55.png"},
+		{"GET", "/Makefile"},
+		{"GET", "/root.html"},
+		{"GET", "/share.png"},
+		{"GET", "/sieve.gif"},
+		{"GET", "/tos.html"},
+		{"GET", "/articles/"},
+		{"GET", "/articles/go_command.html"},
+		{"GET", "/articles/index.html"},
+		{"GET", "/articles/wiki/"},
+		{"GET", "/articles/wiki/edit.html"},
+		{"GET", "/articles/wiki/final-noclosure.go"},
+		{"GET", "/articles/wiki/final-noerror.go"},
+		{"GET", "/articles/wiki/final-parsetemplate.go"},
+		{"GET", "/articles/wiki/final-template.go"},
+		{"GET", "/articles/wiki/final.go"},
+		{"GET", "/articles/wiki/get.go"},
+		{"GET", "/articles/wiki/http-sample.go"},
+		{"GET", "/articles/wiki/index.html"},
+		{"GET", "/articles/wiki/Makefile"},
+		{"GET", "/articles/wiki/notemplate.go"},
+		{"GET", "/articles/wiki/part1-noerror.go"},
+		{"GET", "/articles/wiki/part1.go"},
+		{"GET", "/articles/wiki/part2.go"},
+		{"GET", "/articles/wiki/part3-errorhandling.go"},
+		{"GET", "/articles/wiki/part3.go"},
+		{"GET", "/articles/wiki/test.bash"},
+		{"GET", "/articles/wiki/test_edit.good"},
+		{"GET", "/articles/wiki/test_Test.txt.good"},
+		{"GET", "/articles/wiki/test_view.good"},
+		{"GET", "/articles/wiki/view.html"},
+		{"GET", "/codewalk/"},
+		{"GET", "/codewalk/codewalk.css"},
+		{"GET", "/codewalk/codewalk.js"},
+		{"GET", "/codewalk/codewalk.xml"},
+		{"GET", "/codewalk/functions.xml"},
+		{"GET", "/codewalk/markov.go"},
+		{"GET", "/codewalk/markov.xml"},
+		{"GET", "/codewalk/pig.go"},
+		{"GET", "/codewalk/popout.png"},
+		{"GET", "/codewalk/run"},
+		{"GET", "/codewalk/sharemem.xml"},
+		{"GET", "/codewalk/urlpoll.go"},
+		{"GET", "/devel/"},
+		{"GET", "/devel/release.html"},
+		{"GET", "/devel/weekly.html"},
+		{"GET", "/gopher/"},
+		{"GET", "/gopher/appenginegopher.jpg"},
+		{"GET", "/gopher/appenginegophercolor.jpg"},
+		{"GET", "/gopher/appenginelogo.gif"},
+		{"GET", "/gopher/bumper.png"},
+		{"GET", "/gopher/bumper192x108.png"},
+		{"GET", "/gopher/bumper320x180.png"},
+		{"GET", "/gopher/bumper480x270.png"},
+		{"GET", "/gopher/bumper640x360.png"},
+		{"GET", "/gopher/doc.png"},
+		{"GET", "/gopher/frontpage.png"},
+		{"GET", "/gopher/gopherbw.png"},
+		{"GET", "/gopher/gophercolor.png"},
+		{"GET", "/gopher/gophercolor16x16.png"},
+		{"GET", "/gopher/help.png"},
+		{"GET", "/gopher/pkg.png"},
+		{"GET", "/gopher/project.png"},
+		{"GET", "/gopher/ref.png"},
+		{"GET", "/gopher/run.png"},
+		{"GET", "/gopher/talks.png"},
+		{"GET", "/gopher/pencil/"},
+		{"GET", "/gopher/pencil/gopherhat.jpg"},
+		{"GET", "/gopher/:pencil/gopherhat.jpg"},
+		{"GET", "/gopher/pencil/gopherhelmet.jpg"},
+		{"GET", "/gopher/pencil/gophermega.jpg"},
+		{"GET", "/gopher/pencil/gopherrunning.jpg"},
+		{"GET", "/gopher/pencil/gopherswim.jpg"},
+		{"GET", "/gopher/pencil/gopherswrench.jpg"},
+		{"GET", "/play/"},
+		{"GET", "/play/fib.go"},
+		{"GET", "/play/hello.go"},
+		{"GET", "/play/life.go"},
+		{"GET", "/play/peano.go"},
+		{"GET", "/play/pi.go"},
+		{"GET", "/play/sieve.go"},
+		{"GET", "/play/solitaire.go"},
+		{"GET", "/play/tree.go"},
+		{"GET", "/progs/"},
+		{"GET", "/progs/cgo1.go"},
+		{"GET", "/progs/cgo2.go"},
+		{"GET", "/progs/cgo3.go"},
+		{"GET", "/progs/cgo4.go"},
+		{"GET", "/progs/defer.go"},
+		{"GET", "/progs/defer.out"},
+		{"GET", "/progs/defer2.go"},
+		{"GET", "/progs/defer2.out"},
+		{"GET", "/progs/eff_bytesize.go"},
+		{"GET", "/progs/eff_bytesize.out"},
+		{"GET", "/progs/eff_qr.go"},
+		{"GET", "/progs/eff_sequence.go"},
+		{"GET", "/progs/eff_sequence.out"},
+		{"GET", "/progs/eff_unused1.go"},
+		{"GET", "/progs/eff_unused2.go"},
+		{"GET", "/progs/error.go"},
+		{"GET", "/progs/error2.go"},
+		{"GET", "/progs/error3.go"},
+		{"GET", "/progs/error4.go"},
+		{"GET", "/progs/go1.go"},
+		{"GET", "/progs/gobs1.go"},
+		{"GET", "/progs/gobs2.go"},
+		{"GET", "/progs/image_draw.go"},
+		{"GET", "/progs/image_package1.go"},
+		{"GET", "/progs/image_package1.out"},
+		{"GET", "/progs/image_package2.go"},
+		{"GET", "/progs/image_package2.out"},
+		{"GET", "/progs/image_package3.go"},
+		{"GET", "/progs/image_package3.out"},
+		{"GET", "/progs/image_package4.go"},
+		{"GET", "/progs/image_package4.out"},
+		{"GET", "/progs/image_package5.go"},
+		{"GET", "/progs/image_package5.out"},
+		{"GET", "/progs/image_package6.go"},
+		{"GET", "/progs/image_package6.out"},
+		{"GET", "/progs/interface.go"},
+		{"GET", "/progs/interface2.go"},
+		{"GET", "/progs/interface2.out"},
+		{"GET", "/progs/json1.go"},
+		{"GET", "/progs/json2.go"},
+		{"GET", "/progs/json2.out"},
+		{"GET", "/progs/json3.go"},
+		{"GET", "/progs/json4.go"},
+		{"GET", "/progs/json5.go"},
+		{"GET", "/progs/run"},
+		{"GET", "/progs/slices.go"},
+		{"GET", "/progs/timeout1.go"},
+		{"GET", "/progs/timeout2.go"},
+		{"GET", "/progs/update.bash"},
+	}
+
+	varCaptureRoutes = []*Route{
+		// OAuth Authorizations
+		{"GET", "/authorizations"},
+		{"GET", "/authorizations/{id}"},
+		{"POST", "/authorizations"},
+		{"PUT", "/authorizations/clients/{client_id}"},
+		{"PATCH", "/authorizations/{id}"},
+		{"DELETE", "/authorizations/{id}"},
+		{"GET", "/applications/{client_id}/tokens/{access_token}"},
+		{"DELETE", "/applications/{client_id}/tokens"},
+		{"DELETE", "/applications/{client_id}/tokens/{access_token}"},
+
+		// Activity
+		{"GET", "/events"},
+		{"GET", "/repos/{owner}/{repo}/events"},
+		{"GET", "/networks/{owner}/{repo}/events"},
+		{"GET", "/orgs/{org}/events"},
+		{"GET", "/users/{user}/received_events"},
+		{"GET", "/users/{user}/received_events/public"},
+		{"GET", "/users/{user}/events"},
+		{"GET", "/users/{user}/events/public"},
+		{"GET", "/users/{user}/events/orgs/{org}"},
+		{"GET", "/feeds"},
+		{"GET", "/notifications"},
+		{"GET", "/repos/{owner}/{repo}/notifications"},
+		{"PUT", "/notifications"},
+		{"PUT", "/repos/{owner}/{repo}/notifications"},
+		{"GET", "/notifications/threads/{id}"},
+		{"PATCH", "/notifications/threads/{id}"},
+		{"GET", "/notifications/threads/{id}/subscription"},
+		{"PUT", "/notifications/threads/{id}/subscription"},
+		{"DELETE", "/notifications/threads/{id}/subscription"},
+		{"GET", "/repos/{owner}/{repo}/stargazers"},
+		{"GET", "/users/{user}/starred"},
+		{"GET", "/user/starred"},
+		{"GET", "/user/starred/{owner}/{repo}"},
+		{"PUT", "/user/starred/{owner}/{repo}"},
+		{"DELETE", "/user/starred/{owner}/{repo}"},
+		{"GET", "/repos/{owner}/{repo}/subscribers"},
+		{"GET", "/users/{user}/subscriptions"},
+		{"GET", "/user/subscriptions"},
+		{"GET", "/repos/{owner}/{repo}/subscription"},
+		{"PUT", "/repos/{owner}/{repo}/subscription"},
+		{"DELETE", "/repos/{owner}/{repo}/subscription"},
+		{"GET", "/user/subscriptions/{owner}/{repo}"},
+		{"PUT", "/user/subscriptions/{owner}/{repo}"},
+		{"DELETE", "/user/subscriptions/{owner}/{repo}"},
+
+		// Gists
+		{"GET", "/users/{user}/gists"},
+		{"GET", "/gists"},
+		{"GET", "/gists/public"},
+		{"GET", "/gists/starred"},
+		{"GET", "/gists/{id}"},
+		{"POST", "/gists"},
+		{"PATCH", "/gists/{id}"},
+		{"PUT", "/gists/{id}/star"},
+		{"DELETE", "/gists/{id}/star"},
+		{"GET", "/gists/{id}/star"},
+		{"POST", "/gists/{id}/forks"},
+		{"DELETE", "/gists/{id}"},
+
+		// Git Data
+		{"GET", "/repos/{owner}/{repo}/git/blobs/{sha}"},
+		{"POST", "/repos/{owner}/{repo}/git/blobs"},
+		{"GET", "/repos/{owner}/{repo}/git/commits/{sha}"},
+		{"POST", "/repos/{owner}/{repo}/git/commits"},
+		//{"GET", "/repos/{owner}/{repo}/git/refs/*ref"},
+		{"GET", "/repos/{owner}/{repo}/git/refs"},
+		{"POST", "/repos/{owner}/{repo}/git/refs"},
+		//{"PATCH", "/repos/{owner}/{repo}/git/refs/*ref"},
+		//{"DELETE", "/repos/{owner}/{repo}/git/refs/*ref"},
+		{"GET", "/repos/{owner}/{repo}/git/tags/{sha}"},
+		{"POST", "/repos/{owner}/{repo}/git/tags"},
+		{"GET", "/repos/{owner}/{repo}/git/trees/{sha}"},
+		{"POST", "/repos/{owner}/{repo}/git/trees"},
+
+		// Issues
+		{"GET", "/issues"},
+		{"GET", "/user/issues"},
+		{"GET", "/orgs/{org}/issues"},
+		{"GET", "/repos/{owner}/{repo}/issues"},
+		{"GET", "/repos/{owner}/{repo}/issues/{number}"},
+		{"POST", "/repos/{owner}/{repo}/issues"},
+		{"PATCH", "/repos/{owner}/{repo}/issues/{number}"},
+		{"GET", "/repos/{owner}/{repo}/assignees"},
+		{"GET", "/repos/{owner}/{repo}/assignees/{assignee}"},
+		{"GET", "/repos/{owner}/{repo}/issues/{number}/comments"},
+		//{"GET", "/repos/{owner}/{repo}/issues/comments"},
+		//{"GET", "/repos/{owner}/{repo}/issues/comments/{id}"},
+		{"POST", "/repos/{owner}/{repo}/issues/{number}/comments"},
+		{"PATCH", "/repos/{owner}/{repo}/issues/comments/{id}"},
+		//{"DELETE", "/repos/{owner}/{repo}/issues/comments/{id}"},
+		{"GET", "/repos/{owner}/{repo}/issues/{number}/events"},
+		//{"GET", "/repos/{owner}/{repo}/issues/events"},
+		//{"GET", "/repos/{owner}/{repo}/issues/events/{id}"},
+		{"GET", "/repos/{owner}/{repo}/labels"},
+		{"GET", "/repos/{owner}/{repo}/labels/{name}"},
+		{"POST", "/repos/{owner}/{repo}/labels"},
+		{"PATCH", "/repos/{owner}/{repo}/labels/{name}"},
+		{"DELETE", "/repos/{owner}/{repo}/labels/{name}"},
+		{"GET", "/repos/{owner}/{repo}/issues/{number}/labels"},
+		{"POST", "/repos/{owner}/{repo}/issues/{number}/labels"},
+		{"DELETE", "/repos/{owner}/{repo}/issues/{number}/labels/{name}"},
+		{"PUT", "/repos/{owner}/{repo}/issues/{number}/labels"},
+		{"DELETE", "/repos/{owner}/{repo}/issues/{number}/labels"},
+		{"GET", "/repos/{owner}/{repo}/milestones/{number}/labels"},
+		{"GET", "/repos/{owner}/{repo}/milestones"},
+		{"GET", "/repos/{owner}/{repo}/milestones/{number}"},
+		{"POST", "/repos/{owner}/{repo}/milestones"},
+		{"PATCH", "/repos/{owner}/{repo}/milestones/{number}"},
+		{"DELETE", "/repos/{owner}/{repo}/milestones/{number}"},
+
+		// Miscellaneous
+		{"GET", "/emojis"},
+		{"GET", "/gitignore/templates"},
+		{"GET", "/gitignore/templates/{name}"},
+		{"POST", "/markdown"},
+		{"POST", "/markdown/raw"},
+		{"GET", "/meta"},
+		{"GET", "/rate_limit"},
+
+		// Organizations
+		{"GET", "/users/{user}/orgs"},
+		{"GET", "/user/orgs"},
+		{"GET", "/orgs/{org}"},
+		{"PATCH", "/orgs/{org}"},
+		{"GET", "/orgs/{org}/members"},
+		{"GET", "/orgs/{org}/members/{user}"},
+		{"DELETE", "/orgs/{org}/members/{user}"},
+		{"GET", "/orgs/{org}/public_members"},
+		{"GET", "/orgs/{org}/public_members/{user}"},
+		{"PUT", "/orgs/{org}/public_members/{user}"},
+		{"DELETE", "/orgs/{org}/public_members/{user}"},
+		{"GET", "/orgs/{org}/teams"},
+		{"GET", "/teams/{id}"},
+		{"POST", "/orgs/{org}/teams"},
+		{"PATCH", "/teams/{id}"},
+		{"DELETE", "/teams/{id}"},
+		{"GET", "/teams/{id}/members"},
+		{"GET", "/teams/{id}/members/{user}"},
+		{"PUT", "/teams/{id}/members/{user}"},
+		{"DELETE", "/teams/{id}/members/{user}"},
+		{"GET", "/teams/{id}/repos"},
+		{"GET", "/teams/{id}/repos/{owner}/{repo}"},
+		{"PUT", "/teams/{id}/repos/{owner}/{repo}"},
+		{"DELETE", "/teams/{id}/repos/{owner}/{repo}"},
+		{"GET", "/user/teams"},
+
+		// Pull Requests
+		{"GET", "/repos/{owner}/{repo}/pulls"},
+		{"GET", "/repos/{owner}/{repo}/pulls/{number}"},
+		{"POST", "/repos/{owner}/{repo}/pulls"},
+		{"PATCH", "/repos/{owner}/{repo}/pulls/{number}"},
+		{"GET", "/repos/{owner}/{repo}/pulls/{number}/commits"},
+		{"GET", "/repos/{owner}/{repo}/pulls/{number}/files"},
+		{"GET", "/repos/{owner}/{repo}/pulls/{number}/merge"},
+		{"PUT", "/repos/{owner}/{repo}/pulls/{number}/merge"},
+		{"GET", "/repos/{owner}/{repo}/pulls/{number}/comments"},
+		//{"GET", "/repos/{owner}/{repo}/pulls/comments"},
+		//{"GET", "/repos/{owner}/{repo}/pulls/comments/{number}"},
+		{"PUT", "/repos/{owner}/{repo}/pulls/{number}/comments"},
+		{"PATCH", "/repos/{owner}/{repo}/pulls/comments/{number}"},
+		//{"DELETE", "/repos/{owner}/{repo}/pulls/comments/{number}"},
+
+		// Repositories
+		{"GET", "/user/repos"},
+		{"GET", "/users/{user}/repos"},
+		{"GET", "/orgs/{org}/repos"},
+		{"GET", "/repositories"},
+		{"POST", "/user/repos"},
+		{"POST", "/orgs/{org}/repos"},
+		{"GET", "/repos/{owner}/{repo}"},
+		{"PATCH", "/repos/{owner}/{repo}"},
+		{"GET", "/repos/{owner}/{repo}/contributors"},
+		{"GET", "/repos/{owner}/{repo}/languages"},
+		{"GET", "/repos/{owner}/{repo}/teams"},
+		{"GET", "/repos/{owner}/{repo}/tags"},
+		{"GET", "/repos/{owner}/{repo}/branches"},
+		{"GET", "/repos/{owner}/{repo}/branches/{branch}"},
+		{"DELETE", "/repos/{owner}/{repo}"},
+		{"GET", "/repos/{owner}/{repo}/collaborators"},
+		{"GET", "/repos/{owner}/{repo}/collaborators/{user}"},
+		{"PUT", "/repos/{owner}/{repo}/collaborators/{user}"},
+		{"DELETE", "/repos/{owner}/{repo}/collaborators/{user}"},
+		{"GET", "/repos/{owner}/{repo}/comments"},
+		{"GET", "/repos/{owner}/{repo}/commits/{sha}/comments"},
+		{"POST", "/repos/{owner}/{repo}/commits/{sha}/comments"},
+		{"GET", "/repos/{owner}/{repo}/comments/{id}"},
+		{"PATCH", "/repos/{owner}/{repo}/comments/{id}"},
+		{"DELETE", "/repos/{owner}/{repo}/comments/{id}"},
+		{"GET", "/repos/{owner}/{repo}/commits"},
+		{"GET", "/repos/{owner}/{repo}/commits/{sha}"},
+		{"GET", "/repos/{owner}/{repo}/readme"},
+		//{"GET", "/repos/{owner}/{repo}/contents/*path"},
+		//{"PUT", "/repos/{owner}/{repo}/contents/*path"},
+		//{"DELETE", "/repos/{owner}/{repo}/contents/*path"},
+		//{"GET", "/repos/{owner}/{repo}/{archive_format}/{ref}"},
+		{"GET", "/repos/{owner}/{repo}/keys"},
+		{"GET", "/repos/{owner}/{repo}/keys/{id}"},
+		{"POST", "/repos/{owner}/{repo}/keys"},
+		{"PATCH", "/repos/{owner}/{repo}/keys/{id}"},
+		{"DELETE", "/repos/{owner}/{repo}/keys/{id}"},
+		{"GET", "/repos/{owner}/{repo}/downloads"},
+		{"GET", "/repos/{owner}/{repo}/downloads/{id}"},
+		{"DELETE", "/repos/{owner}/{repo}/downloads/{id}"},
+		{"GET", "/repos/{owner}/{repo}/forks"},
+		{"POST", "/repos/{owner}/{repo}/forks"},
+		{"GET", "/repos/{owner}/{repo}/hooks"},
+		{"GET", "/repos/{owner}/{repo}/hooks/{id}"},
+		{"POST", "/repos/{owner}/{repo}/hooks"},
+		{"PATCH", "/repos/{owner}/{repo}/hooks/{id}"},
+		{"POST", "/repos/{owner}/{repo}/hooks/{id}/tests"},
+		{"DELETE", "/repos/{owner}/{repo}/hooks/{id}"},
+		{"POST", "/repos/{owner}/{repo}/merges"},
+		{"GET", "/repos/{owner}/{repo}/releases"},
+		{"GET", "/repos/{owner}/{repo}/releases/{id}"},
+		{"POST", "/repos/{owner}/{repo}/releases"},
+		{"PATCH", "/repos/{owner}/{repo}/releases/{id}"},
+		{"DELETE", "/repos/{owner}/{repo}/releases/{id}"},
+		{"GET", "/repos/{owner}/{repo}/releases/{id}/assets"},
+		{"GET", "/repos/{owner}/{repo}/stats/contributors"},
+		{"GET", "/repos/{owner}/{repo}/stats/commit_activity"},
+		{"GET", "/repos/{owner}/{repo}/stats/code_frequency"},
+		{"GET", "/repos/{owner}/{repo}/stats/participation"},
+		{"GET", "/repos/{owner}/{repo}/stats/punch_card"},
+		{"GET", "/repos/{owner}/{repo}/statuses/{ref}"},
+		{"POST", "/repos/{owner}/{repo}/statuses/{ref}"},
+
+		// Search
+		{"GET", "/search/repositories"},
+		{"GET", "/search/code"},
+		{"GET", "/search/issues"},
+		{"GET", "/search/users"},
+		{"GET", "/legacy/issues/search/{owner}/{repository}/{state}/{keyword}"},
+		{"GET", "/legacy/repos/search/{keyword}"},
+		{"GET", "/legacy/user/search/{keyword}"},
+		{"GET", "/legacy/user/email/{email}"},
+
+		// Users
+		{"GET", "/users/{user}"},
+		{"GET", "/user"},
+		{"PATCH", "/user"},
+		{"GET", "/users"},
+		{"GET", "/user/emails"},
+		{"POST", "/user/emails"},
+		{"DELETE", "/user/emails"},
+		{"GET", "/users/{user}/followers"},
+		{"GET", "/user/followers"},
+		{"GET", "/users/{user}/following"},
+		{"GET", "/user/following"},
+		{"GET", "/user/following/{user}"},
+		{"GET", "/users/{user}/following/{target_user}"},
+		{"PUT", "/user/following/{user}"},
+		{"DELETE", "/user/following/{user}"},
+		{"GET", "/users/{user}/keys"},
+		{"GET", "/user/keys"},
+		{"GET", "/user/keys/{id}"},
+		{"POST", "/user/keys"},
+		{"PATCH", "/user/keys/{id}"},
+		{"DELETE", "/user/keys/{id}"},
+	}
+
+	// wildcardRoutes contains the GitHub API routes ending with a catch-all variable (*name), next to the routes
+	// that share their prefix
+	wildcardRoutes = []*Route{
+		{"GET", "/repos/{owner}/{repo}"},
+		{"GET", "/repos/{owner}/{repo}/readme"},
+		{"GET", "/repos/{owner}/{repo}/contents/*path"},
+		{"PUT", "/repos/{owner}/{repo}/contents/*path"},
+		{"DELETE", "/repos/{owner}/{repo}/contents/*path"},
+		{"GET", "/repos/{owner}/{repo}/git/refs"},
+		{"GET", "/repos/{owner}/{repo}/git/refs/*ref"},
+		{"POST", "/repos/{owner}/{repo}/git/refs"},
+		{"PATCH", "/repos/{owner}/{repo}/git/refs/*ref"},
+		{"DELETE", "/repos/{owner}/{repo}/git/refs/*ref"},
+		{"GET", "/repos/{owner}/{repo}/git/trees/{sha}"},
+		{"GET", "/users/{user}/repos"},
+		{"GET", "/static/*filepath"},
+	}
+
+	// parseRoutes contains the Parse.com REST API, a short tree with up to two path variables
+	parseRoutes = []*Route{
+		// Objects
+		{"POST", "/1/classes/{className}"},
+		{"GET", "/1/classes/{className}/{objectId}"},
+		{"PUT", "/1/classes/{className}/{objectId}"},
+		{"GET", "/1/classes/{className}"},
+		{"DELETE", "/1/classes/{className}/{objectId}"},
+
+		// Users
+		{"POST", "/1/users"},
+		{"GET", "/1/login"},
+		{"GET", "/1/users/{objectId}"},
+		{"PUT", "/1/users/{objectId}"},
+		{"GET", "/1/users"},
+		{"DELETE", "/1/users/{objectId}"},
+		{"POST", "/1/requestPasswordReset"},
+
+		// Roles
+		{"POST", "/1/roles"},
+		{"GET", "/1/roles/{objectId}"},
+		{"PUT", "/1/roles/{objectId}"},
+		{"GET", "/1/roles"},
+		{"DELETE", "/1/roles/{objectId}"},
+
+		// Files
+		{"POST", "/1/files/{fileName}"},
+
+		// Analytics
+		{"POST", "/1/events/{eventName}"},
+
+		// Push Notifications
+		{"POST", "/1/push"},
+
+		// Installations
+		{"POST", "/1/installations"},
+		{"GET", "/1/installations/{objectId}"},
+		{"PUT", "/1/installations/{objectId}"},
+		{"GET", "/1/installations"},
+		{"DELETE", "/1/installations/{objectId}"},
+
+		// Cloud Functions
+		{"POST", "/1/functions"},
+	}
+
+	// gplusRoutes contains the Google+ API, a shallow tree where most segments after the first one are path variables
+	gplusRoutes = []*Route{
+		// People
+		{"GET", "/people/{userId}"},
+		{"GET", "/people"},
+		{"GET", "/activities/{activityId}/people/{collection}"},
+		{"GET", "/people/{userId}/people/{collection}"},
+		{"GET", "/people/{userId}/openIdConnect"},
+
+		// Activities
+		{"GET", "/people/{userId}/activities/{collection}"},
+		{"GET", "/activities/{activityId}"},
+		{"GET", "/activities"},
+
+		// Comments
+		{"GET", "/activities/{activityId}/comments"},
+		{"GET", "/comments/{commentId}"},
+
+		// Moments
+		{"POST", "/people/{userId}/moments/{collection}"},
+		{"GET", "/people/{userId}/moments/{collection}"},
+		{"DELETE", "/moments/{id}"},
+	}
+
+	// constrainedRoutes declare constraints on their path variables, with a type or a regular expression. Without the
+	// constraints, the routes don't conflict, so the frameworks that can't declare constraints serve them too and
+	// validate the path variables in the handlers.
+	constrainedRoutes = []*Route{
+		{"GET", "/users/{id:int}"},
+		{"PUT", "/users/{id:int}"},
+		{"GET", "/users/{id:int}/orders"},
+		{"GET", "/users/{id:int}/orders/{orderId:uuid}"},
+		{"GET", "/products/{sku:[A-Z]{3}-[0-9]{4}}"},
+		{"GET", "/products/{sku:[A-Z]{3}-[0-9]{4}}/reviews/{page:int}"},
+		{"GET", "/tags/{tag:alpha}"},
+		{"GET", "/archive/{year:[0-9]{4}}/{month:0[1-9]|1[0-2]}/{slug:[a-z0-9-]+}"},
+	}
+)
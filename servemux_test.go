@@ -0,0 +1,91 @@
+package router
+
+import (
+	"net/http"
+	"runtime"
+	"strings"
+)
+
+func init() {
+	registerFramework("servemux", newServeMuxAdapter)
+}
+
+// serveMuxAdapter uses the standard library router with the method and {wildcard} patterns added in Go 1.22
+type serveMuxAdapter struct {
+	mux         *http.ServeMux
+	middlewares []Middleware
+}
+
+func newServeMuxAdapter() FrameworkAdapter {
+	return &serveMuxAdapter{mux: http.NewServeMux()}
+}
+
+func (a *serveMuxAdapter) Name() string {
+	return "servemux"
+}
+
+func (a *serveMuxAdapter) Version() string {
+	return runtime.Version()
+}
+
+// serveMuxSyntax keeps the {name} syntax and converts the *name catch-all variable into {name...}.
+// The ServeMux has no constraints, the handler validates the constrained variables.
+var serveMuxSyntax = pathSyntax{
+	param: bracesParam,
+	catchAll: func(name string) string {
+		return "{" + name + "...}"
+	},
+}
+
+// TranslatePath emits the path with serveMuxSyntax. A path ending with '/' must end with {$}, otherwise the ServeMux
+// treats it as a prefix that matches the whole subtree.
+func (a *serveMuxAdapter) TranslatePath(path string) string {
+	if strings.HasSuffix(path, "/") {
+		return routePattern(path).format(serveMuxSyntax) + "{$}"
+	}
+	return routePattern(path).format(serveMuxSyntax)
+}
+
+// Use adds a middleware wrapping the ServeMux, which has no middlewares
+func (a *serveMuxAdapter) Use(m Middleware) {
+	a.middlewares = append(a.middlewares, m)
+}
+
+func (a *serveMuxAdapter) Register(route *Route, path string, h Handler) (err error) {
+	defer recoverRegistration(&err)
+	handler := serveMuxHandler(route, h)
+	if constraints := routePattern(route.Path).constraints(); constraints != nil {
+		next := handler
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			if !constraints.valid(r.PathValue) {
+				http.NotFound(w, r)
+				return
+			}
+			next(w, r)
+		}
+	}
+	a.mux.HandleFunc(route.Method+" "+path, handler)
+	return nil
+}
+
+func (a *serveMuxAdapter) Build() http.Handler {
+	return wrapHTTPMiddlewares(a.mux, a.middlewares)
+}
+
+func serveMuxHandler(route *Route, h Handler) http.HandlerFunc {
+	switch h {
+	case HandlerRouteInfo:
+		return func(w http.ResponseWriter, r *http.Request) {
+			writePlainText(w, routeInfo(route, r.PathValue))
+		}
+	case HandlerParamRead:
+		params := route.Params()
+		return func(w http.ResponseWriter, r *http.Request) {
+			writePlainText(w, joinParams(params, r.PathValue))
+		}
+	default:
+		return func(w http.ResponseWriter, r *http.Request) {
+			writePlainText(w, "ok")
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package router
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	syntheticDepth  = flag.Int("synthetic.depth", 6, "maximum number of segments of the synthetic routes")
+	syntheticFanOut = flag.Int("synthetic.fanout", 10, "maximum number of static children of a segment of the synthetic routes")
+	syntheticParams = flag.Float64("synthetic.params", 0.2, "ratio of the segments of the synthetic routes that are path variables")
+	syntheticShared = flag.Float64("synthetic.shared", 0.5, "probability that a synthetic route shares the next segment with an existing route")
+	syntheticSeed   = flag.Int64("synthetic.seed", 1, "seed of the synthetic route generator, the same seed generates the same routes")
+	syntheticLarge  = flag.Bool("synthetic.large", false, "run the synthetic benchmark with 100,000 routes, whose registration takes minutes in some frameworks")
+)
+
+// syntheticSampleSize is the number of routes, spread over the whole table, requested by the synthetic benchmarks
+// and checked by the conformance check
+const syntheticSampleSize = 256
+
+// syntheticWords are the static segments of the synthetic routes. Several words share their first characters,
+// like the segments of a real API, to exercise the prefix compression of the radix trees.
+var syntheticWords = []string{
+	"users", "user", "orders", "order", "items", "invoices", "invoice", "accounts", "account", "settings",
+	"search", "repos", "reports", "reviews", "comments", "commits", "events", "export", "files", "folders",
+	"groups", "members", "messages", "metrics", "notifications", "payments", "products", "profiles", "projects", "tags",
+}
+
+// routeTableSpec describes the shape of a synthetic route table
+type routeTableSpec struct {
+	// Routes is the number of generated routes
+	Routes int
+	// Depth is the maximum number of segments of a route. The number of segments is uniformly distributed in [1, Depth]
+	// while the tree has room for the shorter routes.
+	Depth int
+	// FanOut is the maximum number of static children of a segment
+	FanOut int
+	// ParamRatio is the probability that the children of a segment are a path variable instead of static segments
+	ParamRatio float64
+	// SharedPrefix is the probability that a route follows an existing child of a segment instead of adding a new one.
+	// The higher, the more routes share long prefixes.
+	SharedPrefix float64
+	Seed         int64
+}
+
+// routeNode is a segment of the generated route tree. A segment has either static children or a single path variable
+// child, never both, so a request path is matched by at most one route: the table is valid for every framework,
+// including the ones that reject a static segment next to a path variable, and for the first-match routers.
+type routeNode struct {
+	param    *routeNode
+	children []*routeNode
+	names    map[string]bool
+	segment  string
+	isParam  bool
+	decided  bool
+}
+
+// generateRoutes generates the GET routes of a synthetic route table, the same spec always generating the same routes.
+// It returns an error if the tree shape cannot hold the number of routes.
+func generateRoutes(spec routeTableSpec) ([]*Route, error) {
+	if spec.Routes <= 0 || spec.Depth <= 0 || spec.FanOut <= 0 {
+		return nil, fmt.Errorf("invalid route table spec %+v", spec)
+	}
+	rnd := rand.New(rand.NewSource(spec.Seed))
+	// the first segment is always static, a variable would be the only first segment of the table
+	root := &routeNode{decided: true}
+	seen := make(map[string]bool, spec.Routes)
+	routes := make([]*Route, 0, spec.Routes)
+	maxAttempts := 20*spec.Routes + 1000
+	for attempt := 0; len(routes) < spec.Routes; attempt++ {
+		if attempt == maxAttempts {
+			return nil, fmt.Errorf("only %d unique routes generated for %+v, increase the depth or the fan-out", len(routes), spec)
+		}
+		var path strings.Builder
+		node := root
+		length := 1 + rnd.Intn(spec.Depth)
+		for level := 1; level <= spec.Depth; level++ {
+			node = node.next(rnd, spec, level)
+			path.WriteByte('/')
+			path.WriteString(node.segment)
+			// a path that already exists is extended, instead of discarded, once the tree is full at its length
+			if p := path.String(); level >= length && !seen[p] {
+				seen[p] = true
+				routes = append(routes, &Route{Method: http.MethodGet, Path: p})
+				break
+			}
+		}
+	}
+	return routes, nil
+}
+
+// next returns the child followed by a route at the level below the node, adding it if needed
+func (n *routeNode) next(rnd *rand.Rand, spec routeTableSpec, level int) *routeNode {
+	if !n.decided {
+		n.decided = true
+		n.isParam = rnd.Float64() < spec.ParamRatio
+	}
+	if n.isParam {
+		if n.param == nil {
+			// the name depends on the level, so the variables of a route have different names
+			n.param = &routeNode{segment: "{p" + strconv.Itoa(level) + "}"}
+		}
+		return n.param
+	}
+	if len(n.children) > 0 && (len(n.children) >= spec.FanOut || rnd.Float64() < spec.SharedPrefix) {
+		return n.children[rnd.Intn(len(n.children))]
+	}
+	if n.names == nil {
+		n.names = map[string]bool{}
+	}
+	name := syntheticWords[rnd.Intn(len(syntheticWords))]
+	for i := 2; n.names[name]; i++ {
+		name = syntheticWords[rnd.Intn(len(syntheticWords))] + strconv.Itoa(i)
+	}
+	n.names[name] = true
+	child := &routeNode{segment: name}
+	n.children = append(n.children, child)
+	return child
+}
+
+var (
+	syntheticMu   sync.Mutex
+	syntheticSets = map[int]*RouteSet{}
+)
+
+// syntheticRouteSet returns the synthetic route set of the given size, generated on first use from the -synthetic flags
+func syntheticRouteSet(size int, name string) (*RouteSet, error) {
+	syntheticMu.Lock()
+	defer syntheticMu.Unlock()
+	if set, found := syntheticSets[size]; found {
+		return set, nil
+	}
+	routes, err := generateRoutes(routeTableSpec{
+		Routes:       size,
+		Depth:        *syntheticDepth,
+		FanOut:       *syntheticFanOut,
+		ParamRatio:   *syntheticParams,
+		SharedPrefix: *syntheticShared,
+		Seed:         *syntheticSeed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sample := make([]*Route, 0, syntheticSampleSize)
+	step := max(1, len(routes)/syntheticSampleSize)
+	for i := 0; i < len(routes) && len(sample) < syntheticSampleSize; i += step {
+		sample = append(sample, routes[i])
+	}
+	set := &RouteSet{
+		Name:          "synthetic-" + name,
+		Routes:        routes,
+		HotPath:       strings.TrimPrefix(sample[0].URL(), "https://www.domain.com"),
+		CheckedRoutes: sample,
+	}
+	syntheticSets[size] = set
+	return set, nil
+}
+
+// benchmarkSynthetic fires, in turn, the requests to a sample of the routes spread over the whole synthetic table,
+// so the reported time is the average lookup time in a table of that size, wherever the route is
+func benchmarkSynthetic(b *testing.B, size int, name string) {
+	set, err := syntheticRouteSet(size, name)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkScenario(b, &scenario{
+		set:      set,
+		handler:  HandlerOK,
+		requests: routeRequests(set, set.CheckedRoutes),
+		accept:   isStatusOK,
+	})
+}
+
+func Benchmark_Synthetic_1k(b *testing.B) {
+	benchmarkSynthetic(b, 1_000, "1k")
+}
+
+func Benchmark_Synthetic_10k(b *testing.B) {
+	benchmarkSynthetic(b, 10_000, "10k")
+}
+
+func Benchmark_Synthetic_100k(b *testing.B) {
+	if !*syntheticLarge {
+		b.Skip("the registration of 100,000 routes takes minutes in some frameworks, run with -synthetic.large")
+	}
+	benchmarkSynthetic(b, 100_000, "100k")
+}
+
+func TestGenerateRoutes(t *testing.T) {
+	spec := routeTableSpec{Routes: 2000, Depth: 5, FanOut: 8, ParamRatio: 0.25, SharedPrefix: 0.5, Seed: 7}
+	routes, err := generateRoutes(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != spec.Routes {
+		t.Fatalf("generateRoutes() got %d routes, want: %d", len(routes), spec.Routes)
+	}
+
+	again, _ := generateRoutes(spec)
+	seen := map[string]bool{}
+	var segments, params int
+	for i, r := range routes {
+		if r.Path != again[i].Path {
+			t.Fatalf("generateRoutes() is not deterministic: got %s and %s", r.Path, again[i].Path)
+		}
+		if seen[r.Path] {
+			t.Fatalf("generateRoutes() generated %s twice", r.Path)
+		}
+		seen[r.Path] = true
+		parts := strings.Split(r.Path, "/")[1:]
+		if len(parts) > spec.Depth {
+			t.Errorf("%s has more than %d segments", r.Path, spec.Depth)
+		}
+		segments += len(parts)
+		params += len(r.Params())
+	}
+	if ratio := float64(params) / float64(segments); ratio < 0.1 || ratio > 0.4 {
+		t.Errorf("got %.2f path variables per segment, want about %.2f", ratio, spec.ParamRatio)
+	}
+
+	// every path matches only its own route
+	for _, r := range routes[:200] {
+		path := strings.TrimPrefix(r.URL(), "https://www.domain.com")
+		for _, other := range routes {
+			if other != r && other.Matches(path) {
+				t.Fatalf("%s is matched by %s and %s", path, r.Path, other.Path)
+			}
+		}
+	}
+}
+
+func TestGenerateRoutes_tooSmallTree(t *testing.T) {
+	if _, err := generateRoutes(routeTableSpec{Routes: 1000, Depth: 2, FanOut: 3, Seed: 1}); err == nil {
+		t.Error("generateRoutes() expected an error for a tree that cannot hold the routes")
+	}
+}
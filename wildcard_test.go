@@ -0,0 +1,55 @@
+package router
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+// TestWildcardTail verifies that every framework captures the same tail for the catch-all routes,
+// whatever the number of segments of the tail
+func TestWildcardTail(t *testing.T) {
+	tails := []string{
+		"README.md",
+		"heads/main",
+		"tags/v1.1.0-rc.1",
+		"router/path/testdata/very/deep/nested/file.go",
+	}
+
+	clients := map[string]client{}
+	for _, name := range benchmarkedFrameworks() {
+		newClient, err := newClientFactory(name, wildcardRoutes, HandlerParamRead)
+		if err != nil {
+			t.Logf("%s is skipped: %v", name, err)
+			continue
+		}
+		clients[name] = newClient()
+	}
+
+	for _, route := range wildcardRoutes {
+		catchAll := route.CatchAll()
+		if catchAll == "" {
+			continue
+		}
+		for _, tail := range tails {
+			value := func(name string) string {
+				if name == catchAll {
+					return tail
+				}
+				return sampleParamValue(name)
+			}
+			want := joinParams(route.Params(), value)
+			r := httptest.NewRequest(route.Method, route.URLWith(value), nil)
+			for _, name := range benchmarkedFrameworks() {
+				c, found := clients[name]
+				if !found {
+					continue
+				}
+				if status := c.do(r); status != 200 {
+					t.Errorf("%s: %s %s: got status %d, want 200", name, r.Method, r.URL.Path, status)
+				} else if got := string(c.body()); got != want {
+					t.Errorf("%s: %s %s: got %q, want %q", name, r.Method, r.URL.Path, got, want)
+				}
+			}
+		}
+	}
+}